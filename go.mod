@@ -7,6 +7,8 @@ require (
 	fyne.io/x/fyne v0.0.0-20250106132206-3228f6c50107
 	github.com/OpenPrinting/goipp v1.1.0
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/image v0.23.0
+	golang.org/x/sys v0.20.0
 )
 
 require (
@@ -33,10 +35,8 @@ require (
 	github.com/srwiley/oksvg v0.0.0-20221011165216-be6e8873101c // indirect
 	github.com/srwiley/rasterx v0.0.0-20220730225603-2ab79fcdd4ef // indirect
 	github.com/yuin/goldmark v1.7.1 // indirect
-	golang.org/x/image v0.23.0 // indirect
 	golang.org/x/mobile v0.0.0-20231127183840-76ac6878050a // indirect
 	golang.org/x/net v0.25.0 // indirect
-	golang.org/x/sys v0.20.0 // indirect
 	golang.org/x/text v0.21.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,92 @@
+package print
+
+import (
+	"image"
+)
+
+// Booklet describes how logical pages are imposed for saddle-stitched booklets. Each
+// sheet holds four logical pages, two on each side, and the printed stack is folded
+// in half.
+type Booklet struct {
+	// SheetsPerSignature is the number of sheets that are folded together. Zero puts
+	// all of the sheets into a single signature.
+	SheetsPerSignature int
+	// LongEdgeDuplex rotates the back of each sheet by 180 degrees for printers that
+	// can only flip sheets on the long edge. By default, the sides are laid out for
+	// short-edge duplex printing.
+	LongEdgeDuplex bool
+}
+
+// Order returns the logical page indices in the order that they are printed. Each pair
+// of values is the left and right page of one side of a sheet, and a value of -1 is a
+// blank page that pads the signature to a multiple of four pages.
+//
+// Params:
+//
+//	pageCount is the number of logical pages.
+func (b Booklet) Order(pageCount int) []int {
+	sigPages := pageCount
+	if b.SheetsPerSignature > 0 {
+		sigPages = b.SheetsPerSignature * 4
+	}
+	var order []int
+	for start := 0; start < pageCount; start += sigPages {
+		n := sigPages
+		if start+n > pageCount {
+			n = pageCount - start
+		}
+		n = (n + 3) / 4 * 4
+		page := func(i int) int {
+			if start+i >= pageCount || i >= n {
+				return -1
+			}
+			return start + i
+		}
+		for s := 0; s < n/4; s++ {
+			order = append(order,
+				page(n-1-2*s), page(2*s),
+				page(2*s+1), page(n-2-2*s))
+		}
+	}
+	return order
+}
+
+// Impose arranges the logical pages onto the sides of booklet sheets. The sheets are
+// the same size as the first page, with the two pages of each side rotated to fit
+// side by side.
+func (b Booklet) Impose(pages []image.Image) []image.Image {
+	if len(pages) == 0 {
+		return pages
+	}
+	pageRect := pages[0].Bounds()
+	// each side is laid out in landscape and then rotated onto the portrait sheet
+	sideRect := image.Rect(0, 0, pageRect.Dy(), pageRect.Dx())
+	left := image.Rect(0, 0, sideRect.Dx()/2, sideRect.Dy())
+	right := image.Rect(sideRect.Dx()/2, 0, sideRect.Dx(), sideRect.Dy())
+
+	order := b.Order(len(pages))
+	var sides []image.Image
+	for i := 0; i < len(order); i += 2 {
+		side := image.NewNRGBA(sideRect)
+		if order[i] >= 0 {
+			drawFitted(side, left, pages[order[i]])
+		}
+		if order[i+1] >= 0 {
+			drawFitted(side, right, pages[order[i+1]])
+		}
+		sheet := rotate90(side)
+		if b.LongEdgeDuplex && (i/2)%2 == 1 {
+			sheet = rotate180(sheet)
+		}
+		sides = append(sides, sheet)
+	}
+	return sides
+}
+
+// Sides returns the IPP "sides" value that must be used to print the booklet.
+func (b Booklet) Sides() string {
+	if b.LongEdgeDuplex {
+		return "two-sided-long-edge"
+	}
+	return "two-sided-short-edge"
+}
@@ -0,0 +1,59 @@
+package print
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBooklet_Order(t *testing.T) {
+	b := Booklet{}
+	assert.Equal(t, []int{3, 0, 1, 2}, b.Order(4))
+	assert.Equal(t, []int{7, 0, 1, 6, 5, 2, 3, 4}, b.Order(8))
+	assert.Equal(t, []int{-1, 0, 1, -1, -1, 2, 3, 4}, b.Order(5))
+
+	b.SheetsPerSignature = 1
+	assert.Equal(t, []int{3, 0, 1, 2, 7, 4, 5, 6, -1, 8, 9, -1}, b.Order(10))
+	assert.Empty(t, b.Order(0))
+}
+
+func TestBooklet_Impose(t *testing.T) {
+	pages := []image.Image{
+		newTestPage(40, 60, color.Black),
+		newTestPage(40, 60, color.Black),
+		newTestPage(40, 60, color.Black),
+	}
+	sides := Booklet{}.Impose(pages)
+	assert.Len(t, sides, 2)
+	assert.Equal(t, image.Rect(0, 0, 40, 60), sides[0].Bounds())
+	// the front side holds the blank last page and the first page
+	assert.Equal(t, uint32(0), alphaAt(sides[0], 20, 15))
+	assert.NotEqual(t, uint32(0), alphaAt(sides[0], 20, 45))
+	assert.NotEqual(t, uint32(0), alphaAt(sides[1], 20, 15))
+	assert.NotEqual(t, uint32(0), alphaAt(sides[1], 20, 45))
+
+	assert.Empty(t, Booklet{}.Impose(nil))
+}
+
+func TestBooklet_Sides(t *testing.T) {
+	assert.Equal(t, "two-sided-short-edge", Booklet{}.Sides())
+	assert.Equal(t, "two-sided-long-edge", Booklet{LongEdgeDuplex: true}.Sides())
+}
+
+func TestRotate(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	img.Set(0, 0, color.Black)
+	r := rotate90(img)
+	assert.Equal(t, image.Rect(0, 0, 2, 3), r.Bounds())
+	assert.Equal(t, uint32(0xffff), alphaAt(r, 1, 0))
+	r = rotate180(img)
+	assert.Equal(t, uint32(0xffff), alphaAt(r, 2, 1))
+}
+
+// alphaAt returns the alpha value of the pixel at x, y.
+func alphaAt(img image.Image, x, y int) uint32 {
+	_, _, _, a := img.At(x, y).RGBA()
+	return a
+}
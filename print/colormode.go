@@ -0,0 +1,147 @@
+package print
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// ColorMode is the color mode that pages are printed in. The modes match the values of
+// the IPP "print-color-mode" attribute.
+type ColorMode int
+
+const (
+	ColorModeColor      ColorMode = iota // pages are printed as rendered
+	ColorModeMonochrome                  // pages are converted to 8-bit grayscale
+	ColorModeBiLevel                     // pages are converted to black and white
+)
+
+// colorModeNames are the IPP keywords for the ColorMode values.
+var colorModeNames = []string{"color", "monochrome", "bi-level"}
+
+// String returns the IPP keyword for the color mode.
+func (m ColorMode) String() string {
+	if m < 0 || int(m) >= len(colorModeNames) {
+		return colorModeNames[ColorModeColor]
+	}
+	return colorModeNames[m]
+}
+
+// ParseColorMode converts an IPP "print-color-mode" keyword to a ColorMode value.
+// The "auto" keyword prints in color.
+func ParseColorMode(keyword string) (ColorMode, error) {
+	if keyword == "auto" {
+		return ColorModeColor, nil
+	}
+	for i, name := range colorModeNames {
+		if name == keyword {
+			return ColorMode(i), nil
+		}
+	}
+	return ColorModeColor, fmt.Errorf("unknown print-color-mode value: %s", keyword)
+}
+
+// Dither is the method used to convert grayscale pages to black and white.
+type Dither int
+
+const (
+	DitherThreshold      Dither = iota // pixels darker than mid-gray are black
+	DitherOrdered                      // pixels are compared to an 8x8 Bayer matrix
+	DitherFloydSteinberg               // errors are diffused to neighbouring pixels
+)
+
+// bayer8 is the 8x8 Bayer threshold matrix used for ordered dithering.
+var bayer8 = [8][8]int{
+	{0, 32, 8, 40, 2, 34, 10, 42},
+	{48, 16, 56, 24, 50, 18, 58, 26},
+	{12, 44, 4, 36, 14, 46, 6, 38},
+	{60, 28, 52, 20, 62, 30, 54, 22},
+	{3, 35, 11, 43, 1, 33, 9, 41},
+	{51, 19, 59, 27, 49, 17, 57, 25},
+	{15, 47, 7, 39, 13, 45, 5, 37},
+	{63, 31, 55, 23, 61, 29, 53, 21},
+}
+
+// biLevelPalette is the palette of black and white pages.
+var biLevelPalette = color.Palette{color.Black, color.White}
+
+// ColorConversion describes how rendered pages are converted before they are encoded.
+type ColorConversion struct {
+	Mode ColorMode
+	// Dither is the dithering method used by ColorModeBiLevel.
+	Dither Dither
+}
+
+// Apply converts each page to the color mode. Transparent pixels are composited onto
+// white paper, and grayscale values are weighted by the luminance of each color.
+func (cc ColorConversion) Apply(pages []image.Image) []image.Image {
+	if cc.Mode == ColorModeColor {
+		return pages
+	}
+	converted := make([]image.Image, len(pages))
+	for i, page := range pages {
+		gray := grayscale(page)
+		if cc.Mode == ColorModeBiLevel {
+			converted[i] = cc.dither(gray)
+		} else {
+			converted[i] = gray
+		}
+	}
+	return converted
+}
+
+// grayscale returns the luminance of the image composited onto white.
+func grayscale(img image.Image) *image.Gray {
+	b := img.Bounds()
+	gray := image.NewGray(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl := onWhite(img.At(x, y))
+			gray.SetGray(x, y, color.GrayModel.Convert(color.RGBA{R: r, G: g, B: bl,
+				A: 0xff}).(color.Gray))
+		}
+	}
+	return gray
+}
+
+// dither converts a grayscale image to black and white.
+func (cc ColorConversion) dither(gray *image.Gray) *image.Paletted {
+	b := gray.Bounds()
+	bw := image.NewPaletted(b, biLevelPalette)
+	var errs [2][]int
+	if cc.Dither == DitherFloydSteinberg {
+		errs = [2][]int{make([]int, b.Dx()+2), make([]int, b.Dx()+2)}
+	}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			v := int(gray.GrayAt(x, y).Y)
+			threshold := 128
+			switch cc.Dither {
+			case DitherOrdered:
+				threshold = bayer8[(y-b.Min.Y)%8][(x-b.Min.X)%8]*4 + 2
+			case DitherFloydSteinberg:
+				i := x - b.Min.X + 1
+				v += errs[0][i] / 16
+				out := 0
+				if v >= threshold {
+					out = 255
+				}
+				e := v - out
+				errs[0][i+1] += e * 7
+				errs[1][i-1] += e * 3
+				errs[1][i] += e * 5
+				errs[1][i+1] += e
+			}
+			if v >= threshold {
+				bw.SetColorIndex(x, y, 1)
+			}
+		}
+		if cc.Dither == DitherFloydSteinberg {
+			errs[0], errs[1] = errs[1], errs[0]
+			for i := range errs[1] {
+				errs[1][i] = 0
+			}
+		}
+	}
+	return bw
+}
@@ -0,0 +1,53 @@
+package print
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseColorMode(t *testing.T) {
+	for _, m := range []ColorMode{ColorModeColor, ColorModeMonochrome, ColorModeBiLevel} {
+		parsed, err := ParseColorMode(m.String())
+		assert.Nil(t, err)
+		assert.Equal(t, m, parsed)
+	}
+	mode, err := ParseColorMode("auto")
+	assert.Nil(t, err)
+	assert.Equal(t, ColorModeColor, mode)
+	_, err = ParseColorMode("process-monochrome")
+	assert.NotNil(t, err)
+}
+
+func TestColorConversion_Monochrome(t *testing.T) {
+	pages := []image.Image{newTestPage(2, 2, color.RGBA{R: 0xff, A: 0xff}),
+		newTestPage(2, 2, color.Transparent)}
+	assert.Equal(t, pages, ColorConversion{}.Apply(pages))
+
+	gray := ColorConversion{Mode: ColorModeMonochrome}.Apply(pages)
+	assert.Equal(t, color.Gray{Y: 76}, gray[0].At(0, 0))
+	assert.Equal(t, color.Gray{Y: 0xff}, gray[1].At(1, 1))
+}
+
+func TestColorConversion_BiLevel(t *testing.T) {
+	mid := newTestPage(16, 16, color.Gray{Y: 0x80})
+	for _, d := range []Dither{DitherThreshold, DitherOrdered, DitherFloydSteinberg} {
+		bw := ColorConversion{Mode: ColorModeBiLevel, Dither: d}.Apply([]image.Image{mid})[0]
+		white := 0
+		for y := 0; y < 16; y++ {
+			for x := 0; x < 16; x++ {
+				if bw.At(x, y) == color.White {
+					white++
+				}
+			}
+		}
+		switch d {
+		case DitherThreshold:
+			assert.Equal(t, 256, white)
+		default:
+			assert.InDelta(t, 128, white, 8, "dither %d", d)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+//go:build !windows
+
+package print
+
+import (
+	"strings"
+
+	"github.com/OpenPrinting/goipp"
+)
+
+// resolveWithValidate finds the options that conflict with a changed option using the
+// IPP Validate-Job operation, and resolves the conflicts by replacing the other
+// conflicting options with the printer's defaults. Options without a default are
+// removed.
+//
+// Params:
+//
+//	uri is the printer URI.
+//	options are the current job options.
+//	option and value are the option that is changed and its new value.
+func resolveWithValidate(uri string, options JobOptions, option, value string) (
+	OptionConflicts, error) {
+	changed := JobOptions{option: value}
+	for name, v := range options {
+		if name != option {
+			changed[name] = v
+		}
+	}
+	conflicts, err := validateJob(uri, changed, "")
+	if err != nil || conflicts == nil {
+		return OptionConflicts{}, err
+	}
+	result := OptionConflicts{Conflicting: JobOptions{}}
+	var defaults []string
+	for _, c := range conflicts {
+		if c.Option != "" {
+			result.Conflicting[c.Option] = c.Value
+			defaults = append(defaults, c.Option+"-default")
+		}
+	}
+	if len(result.Conflicting) == 0 {
+		return OptionConflicts{}, conflicts
+	}
+	if _, ok := result.Conflicting[option]; ok {
+		// the new value is not supported, so changing other options cannot help
+		return result, nil
+	}
+	result.Conflicting[option] = value
+	groups, err := getResponseGroups(goipp.OpGetPrinterAttributes, uri,
+		strings.Join(defaults, " "))
+	if err != nil {
+		return result, err
+	}
+	result.Resolved = JobOptions{}
+	for name, v := range result.Conflicting {
+		if name == option {
+			continue
+		}
+		result.Resolved[name] = ""
+		if d := attributeStrings(groups, name+"-default"); len(d) > 0 && d[0] != v {
+			result.Resolved[name] = d[0]
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,35 @@
+//go:build !windows
+
+package print
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveWithValidate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(validateStandIn))
+	defer server.Close()
+
+	options := JobOptions{"media-type": "transparency", "copies": "2"}
+	conflicts, err := resolveWithValidate(server.URL, options, "sides", "one-sided")
+	assert.Nil(t, err)
+	assert.False(t, conflicts.HasConflict())
+
+	conflicts, err = resolveWithValidate(server.URL, options, "sides", "two-sided-long-edge")
+	assert.Nil(t, err)
+	assert.Equal(t, JobOptions{"sides": "two-sided-long-edge", "media-type": "transparency"},
+		conflicts.Conflicting)
+	assert.Equal(t, JobOptions{"media-type": "stationery"}, conflicts.Resolved)
+	assert.Equal(t, JobOptions{"sides": "two-sided-long-edge", "media-type": "stationery",
+		"copies": "2"}, conflicts.Apply(JobOptions{"sides": "two-sided-long-edge",
+		"media-type": "transparency", "copies": "2"}))
+
+	conflicts, err = resolveWithValidate(server.URL, options, "sides", "two-sided-short-edge")
+	assert.Nil(t, err)
+	assert.True(t, conflicts.HasConflict())
+	assert.False(t, conflicts.Resolvable())
+}
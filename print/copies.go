@@ -0,0 +1,100 @@
+package print
+
+import (
+	"image"
+	"strconv"
+)
+
+// Copies describes how many copies of a job are printed and whether they are collated.
+type Copies struct {
+	// Count is the number of copies. Zero prints one copy.
+	Count int
+	// Collate prints each copy of the whole job before the next copy. Uncollated copies
+	// print every copy of a page before the next page.
+	Collate bool
+}
+
+// Sequence returns the sheets of every copy in the order that they are printed. It is
+// used for printers that cannot make the copies themselves.
+//
+// Params:
+//
+//	sheets are the sheets of one copy.
+func (c Copies) Sequence(sheets []image.Image) []image.Image {
+	return c.sequence(sheets, 1)
+}
+
+// sequenceSides returns the sides of every copy in the order that they are printed.
+// Two-sided copies keep both sides of each sheet together, and a blank side is added to
+// a copy with an odd number of sides so that the next copy starts on a new sheet.
+//
+// Params:
+//
+//	sides are the sides of one copy.
+//	twoSided is true if the printer prints on both sides of the sheets.
+func (c Copies) sequenceSides(sides []image.Image, twoSided bool) []image.Image {
+	if !twoSided || c.Count <= 1 {
+		return c.Sequence(sides)
+	}
+	return c.sequence(padSides(sides), 2)
+}
+
+// sequence returns the sheets of every copy in the order that they are printed. When the
+// copies are not collated, each group of groupSize sheets is repeated together, so that
+// both sides of a two-sided sheet stay together.
+func (c Copies) sequence(sheets []image.Image, groupSize int) []image.Image {
+	if c.Count <= 1 {
+		return sheets
+	}
+	seq := make([]image.Image, 0, len(sheets)*c.Count)
+	if c.Collate {
+		for n := 0; n < c.Count; n++ {
+			seq = append(seq, sheets...)
+		}
+		return seq
+	}
+	for start := 0; start < len(sheets); start += groupSize {
+		end := start + groupSize
+		if end > len(sheets) {
+			end = len(sheets)
+		}
+		for n := 0; n < c.Count; n++ {
+			seq = append(seq, sheets[start:end]...)
+		}
+	}
+	return seq
+}
+
+// padSides adds a blank side after the last side if there is an odd number of sides, so
+// that every sheet has a front and a back.
+func padSides(sides []image.Image) []image.Image {
+	if len(sides)%2 == 0 {
+		return sides
+	}
+	return append(sides, image.NewNRGBA(sides[len(sides)-1].Bounds()))
+}
+
+// jobOptions returns the IPP "copies" and "multiple-document-handling" options that
+// let the printer make the copies. False is returned if the printer cannot make the
+// copies as requested, in which case they must be generated with Sequence.
+//
+// Params:
+//
+//	canCopy is true if the printer can print multiple copies.
+//	canCollate is true if the printer can collate copies.
+func (c Copies) jobOptions(canCopy, canCollate bool) (map[string]string, bool) {
+	if c.Count <= 1 {
+		return map[string]string{}, true
+	}
+	if !canCopy || (c.Collate && !canCollate) {
+		return nil, false
+	}
+	handling := "separate-documents-uncollated-copies"
+	if c.Collate {
+		handling = "separate-documents-collated-copies"
+	}
+	return map[string]string{
+		"copies":                     strconv.Itoa(c.Count),
+		"multiple-document-handling": handling,
+	}, true
+}
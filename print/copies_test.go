@@ -0,0 +1,69 @@
+package print
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopies_Sequence(t *testing.T) {
+	a := newTestPage(1, 1, color.Black)
+	b := newTestPage(2, 2, color.Black)
+	sheets := []image.Image{a, b}
+	assert.Equal(t, sheets, Copies{}.Sequence(sheets))
+	assert.Equal(t, []image.Image{a, b, a, b}, Copies{Count: 2, Collate: true}.Sequence(sheets))
+	assert.Equal(t, []image.Image{a, a, b, b}, Copies{Count: 2}.Sequence(sheets))
+}
+
+func TestCopies_SequenceGroups(t *testing.T) {
+	a := newTestPage(1, 1, color.Black)
+	b := newTestPage(2, 2, color.Black)
+	c := newTestPage(3, 3, color.Black)
+	d := newTestPage(4, 4, color.Black)
+	assert.Equal(t, []image.Image{a, b, a, b, c, d, c, d},
+		Copies{Count: 2}.sequence([]image.Image{a, b, c, d}, 2))
+}
+
+func TestCopies_SequenceSides(t *testing.T) {
+	a := newTestPage(1, 1, color.Black)
+	b := newTestPage(2, 2, color.Black)
+	c := newTestPage(3, 3, color.Black)
+	sides := []image.Image{a, b, c}
+	assert.Equal(t, []image.Image{a, a, b, b, c, c}, Copies{Count: 2}.sequenceSides(sides, false))
+	assert.Equal(t, sides, Copies{Count: 1}.sequenceSides(sides, true))
+
+	seq := Copies{Count: 2}.sequenceSides(sides, true)
+	assert.Len(t, seq, 8)
+	assert.Equal(t, []image.Image{a, b, a, b, c}, seq[:5])
+	// the last sheet of each copy gets a blank back
+	assert.Equal(t, c, seq[6])
+	assert.Equal(t, seq[5], seq[7])
+	assert.Equal(t, image.Rect(0, 0, 3, 3), seq[5].Bounds())
+
+	seq = Copies{Count: 2, Collate: true}.sequenceSides(sides, true)
+	assert.Equal(t, []image.Image{a, b, c}, seq[:3])
+	assert.Equal(t, []image.Image{a, b, c}, seq[4:7])
+}
+
+func TestCopies_JobOptions(t *testing.T) {
+	options, ok := Copies{Count: 1}.jobOptions(false, false)
+	assert.True(t, ok)
+	assert.Empty(t, options)
+
+	options, ok = Copies{Count: 3, Collate: true}.jobOptions(true, true)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"copies": "3",
+		"multiple-document-handling": "separate-documents-collated-copies"}, options)
+
+	options, ok = Copies{Count: 3}.jobOptions(true, false)
+	assert.True(t, ok)
+	assert.Equal(t, "separate-documents-uncollated-copies",
+		options["multiple-document-handling"])
+
+	_, ok = Copies{Count: 3, Collate: true}.jobOptions(true, false)
+	assert.False(t, ok)
+	_, ok = Copies{Count: 3}.jobOptions(false, true)
+	assert.False(t, ok)
+}
@@ -131,8 +131,8 @@ func (d *devMode) PrintQuality() printQuality {
 
 // Color indicates whether a color printer should print in color or monochrome mode.
 // The returned value is one of DMCOLOR_COLOR or DMCOLOR_MONOCHROME.
-func (d *devMode) Color() color {
-	return color(d.dmColor)
+func (d *devMode) Color() dmColor {
+	return dmColor(d.dmColor)
 }
 
 // Duplex specifies duplex (double-sided) printing for duplex-capable printers.
@@ -839,6 +839,28 @@ func (d defaultSource) String() string {
 	}
 }
 
+// mediaSource returns the IPP "media-source" keyword for the default source, or an
+// empty string if there is no matching keyword.
+func (d defaultSource) mediaSource() string {
+	switch d {
+	case C.DMBIN_UPPER:
+		return "top"
+	case C.DMBIN_MIDDLE:
+		return "middle"
+	case C.DMBIN_LOWER:
+		return "bottom"
+	case C.DMBIN_MANUAL:
+		return "manual"
+	case C.DMBIN_ENVELOPE, C.DMBIN_ENVMANUAL:
+		return "envelope"
+	case C.DMBIN_AUTO:
+		return "auto"
+	case C.DMBIN_LARGECAPACITY:
+		return "large-capacity"
+	}
+	return ""
+}
+
 // printQuality defines the print quality, either as set string or as dpi.
 type printQuality int16
 
@@ -864,11 +886,11 @@ func (p printQuality) String() string {
 	}
 }
 
-// color defines the color setting (color or monochrome) for the printer.
-type color uint16
+// dmColor defines the color setting (color or monochrome) for the printer.
+type dmColor uint16
 
 // String returns the color value as a string.
-func (c color) String() string {
+func (c dmColor) String() string {
 	switch c {
 	case C.DMCOLOR_MONOCHROME:
 		return "Monochrome"
@@ -1002,6 +1024,21 @@ func (i icmIntent) String() string {
 	}
 }
 
+// renderingIntent returns the ICC RenderingIntent that matches the icmIntent. Unknown
+// values select the perceptual intent.
+func (i icmIntent) renderingIntent() RenderingIntent {
+	switch i {
+	case C.DMICM_SATURATE:
+		return IntentSaturation
+	case C.DMICM_COLORIMETRIC:
+		return IntentRelativeColorimetric
+	case C.DMICM_ABS_COLORIMETRIC:
+		return IntentAbsoluteColorimetric
+	default:
+		return IntentPerceptual
+	}
+}
+
 // mediaType specifies the type of media being printed on.
 type mediaType uint32
 
@@ -1059,3 +1096,19 @@ func (d ditherType) String() string {
 		return "Invalid value"
 	}
 }
+
+// dither returns the Dither method that matches the ditherType. False is returned for
+// DMDITHER_GRAYSCALE and for driver defined dither types, which are printed in
+// grayscale.
+func (d ditherType) dither() (Dither, bool) {
+	switch d {
+	case C.DMDITHER_NONE, C.DMDITHER_LINEART:
+		return DitherThreshold, true
+	case C.DMDITHER_COARSE, C.DMDITHER_FINE:
+		return DitherOrdered, true
+	case C.DMDITHER_ERRORDIFFUSION:
+		return DitherFloydSteinberg, true
+	default:
+		return DitherThreshold, false
+	}
+}
@@ -0,0 +1,166 @@
+package print
+
+import (
+	"image"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/layout"
+)
+
+// PageHint controls how an object in a Document is paginated.
+type PageHint int
+
+const (
+	// KeepTogether prevents a container from being split between its child objects.
+	KeepTogether PageHint = 1 << iota
+	// PageBreakBefore starts a new page before the object.
+	PageBreakBefore
+)
+
+// docObject is an object in a Document together with its pagination hints.
+type docObject struct {
+	obj   fyne.CanvasObject
+	hints PageHint
+}
+
+// pageSlice is the vertical part of a document's content that is printed on one page.
+type pageSlice struct {
+	top    float32
+	bottom float32
+}
+
+// breakUnit is a part of the document's content that is not split between pages unless
+// it is taller than a page.
+type breakUnit struct {
+	top         float32
+	bottom      float32
+	breakBefore bool
+}
+
+// Document is a sequence of fyne objects that is split into pages when printed.
+// Objects are stacked vertically at the width of the imageable area of the page.
+type Document struct {
+	title   string
+	objects []docObject
+}
+
+// NewDocument creates an empty Document.
+//
+// Params:
+//
+//	title is the document title. It is used as the print job's title.
+func NewDocument(title string) *Document {
+	return &Document{title: title}
+}
+
+// Title returns the document's title.
+func (d *Document) Title() string {
+	return d.title
+}
+
+// Add appends an object to the document.
+//
+// Params:
+//
+//	obj is the object to add. Pages are broken between objects. If obj is a container,
+//	pages may also be broken between its child objects unless the KeepTogether hint is
+//	given.
+//	hints are the pagination hints for the object.
+func (d *Document) Add(obj fyne.CanvasObject, hints ...PageHint) {
+	var h PageHint
+	for _, hint := range hints {
+		h |= hint
+	}
+	d.objects = append(d.objects, docObject{obj: obj, hints: h})
+}
+
+// Objects returns the objects in the document.
+func (d *Document) Objects() []fyne.CanvasObject {
+	objs := make([]fyne.CanvasObject, len(d.objects))
+	for i, o := range d.objects {
+		objs[i] = o.obj
+	}
+	return objs
+}
+
+// PageCount returns the number of pages that the document needs when it is printed
+// using the specified print context.
+func (d *Document) PageCount(pc *PrintContext) int {
+	_, slices := d.layout(pc.ImageableSize())
+	return len(slices)
+}
+
+// Pages renders each page of the document. The content of each page is placed within
+// the imageable area of the page.
+func (d *Document) Pages(pc *PrintContext) []image.Image {
+	content, slices := d.layout(pc.ImageableSize())
+	pages := make([]image.Image, len(slices))
+	for i, s := range slices {
+		page := pc.newPageImage()
+		region := fyne.NewSize(pc.ImageableSize().Width, s.bottom-s.top)
+		pc.renderRegion(page, content, region, fyne.NewPos(0, s.top), pc.ImageableOrigin())
+		pages[i] = page
+	}
+	return pages
+}
+
+// layout stacks the document's objects at the imageable width and determines where
+// the pages are broken.
+func (d *Document) layout(imageable fyne.Size) (fyne.CanvasObject, []pageSlice) {
+	objs := d.Objects()
+	content := &fyne.Container{Layout: layout.NewVBoxLayout(), Objects: objs}
+	content.Resize(fyne.NewSize(imageable.Width, content.MinSize().Height))
+	content.Move(fyne.NewPos(0, 0))
+
+	var units []breakUnit
+	for _, o := range d.objects {
+		units = append(units, objectUnits(o)...)
+	}
+	return content, paginate(units, imageable.Height)
+}
+
+// objectUnits returns the break units for a document object that has been laid out.
+func objectUnits(o docObject) []breakUnit {
+	pos := o.obj.Position()
+	c, ok := o.obj.(*fyne.Container)
+	if !ok || o.hints&KeepTogether != 0 || len(c.Objects) == 0 {
+		return []breakUnit{{top: pos.Y, bottom: pos.Y + o.obj.Size().Height,
+			breakBefore: o.hints&PageBreakBefore != 0}}
+	}
+	units := make([]breakUnit, 0, len(c.Objects))
+	for i, child := range c.Objects {
+		top := pos.Y + child.Position().Y
+		units = append(units, breakUnit{top: top, bottom: top + child.Size().Height,
+			breakBefore: i == 0 && o.hints&PageBreakBefore != 0})
+	}
+	return units
+}
+
+// paginate splits the break units into page slices no taller than pageHeight. Units that
+// are taller than a page are split at the page height.
+func paginate(units []breakUnit, pageHeight float32) []pageSlice {
+	if len(units) == 0 || pageHeight <= 0 {
+		return nil
+	}
+	var slices []pageSlice
+	current := pageSlice{top: units[0].top, bottom: units[0].top}
+	for _, u := range units {
+		startNew := u.breakBefore || u.bottom-current.top > pageHeight
+		if startNew && current.bottom > current.top {
+			slices = append(slices, current)
+			current = pageSlice{top: u.top, bottom: u.top}
+		}
+		for u.bottom-current.top > pageHeight {
+			current.bottom = current.top + pageHeight
+			slices = append(slices, current)
+			current = pageSlice{top: current.bottom, bottom: current.bottom}
+		}
+		if u.bottom > current.bottom {
+			current.bottom = u.bottom
+		}
+	}
+	if current.bottom > current.top {
+		slices = append(slices, current)
+	}
+	return slices
+}
@@ -0,0 +1,82 @@
+package print
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginate(t *testing.T) {
+	units := []breakUnit{
+		{top: 0, bottom: 40},
+		{top: 40, bottom: 80},
+		{top: 80, bottom: 120},
+		{top: 120, bottom: 130, breakBefore: true},
+	}
+	assert.Equal(t, []pageSlice{{0, 80}, {80, 120}, {120, 130}}, paginate(units, 100))
+
+	tall := []breakUnit{{top: 0, bottom: 10}, {top: 10, bottom: 260}}
+	assert.Equal(t, []pageSlice{{0, 10}, {10, 110}, {110, 210}, {210, 260}}, paginate(tall, 100))
+
+	assert.Nil(t, paginate(nil, 100))
+}
+
+func newTestRect(h float32) *canvas.Rectangle {
+	r := canvas.NewRectangle(color.Black)
+	r.SetMinSize(fyne.NewSize(10, h))
+	return r
+}
+
+func TestDocument_PageCount(t *testing.T) {
+	test.NewApp()
+	pc := newPrintContext(fyne.NewSize(200, 120), Margins{top: 10, bottom: 10, left: 10, right: 10}, 72)
+	doc := NewDocument("Test")
+	doc.Add(newTestRect(60))
+	doc.Add(newTestRect(60))
+	assert.Equal(t, 2, doc.PageCount(pc))
+
+	doc = NewDocument("Test")
+	doc.Add(newTestRect(10))
+	doc.Add(newTestRect(10), PageBreakBefore)
+	assert.Equal(t, 2, doc.PageCount(pc))
+}
+
+func TestDocument_KeepTogether(t *testing.T) {
+	test.NewApp()
+	pc := newPrintContext(fyne.NewSize(200, 100), Margins{}, 72)
+	box := container.New(layout.NewVBoxLayout(), newTestRect(60), newTestRect(60))
+
+	doc := NewDocument("Split")
+	doc.Add(newTestRect(30))
+	doc.Add(box)
+	assert.Equal(t, 2, doc.PageCount(pc))
+
+	doc = NewDocument("Together")
+	doc.Add(newTestRect(30))
+	doc.Add(box, KeepTogether)
+	assert.Equal(t, 3, doc.PageCount(pc))
+}
+
+func TestDocument_Pages(t *testing.T) {
+	test.NewApp()
+	pc := newPrintContext(fyne.NewSize(100, 100), Margins{top: 10, bottom: 10, left: 10, right: 10}, 144)
+	doc := NewDocument("Test")
+	doc.Add(newTestRect(50))
+	doc.Add(newTestRect(50))
+	pages := doc.Pages(pc)
+	assert.Equal(t, 2, len(pages))
+	assert.Equal(t, 200, pages[0].Bounds().Dx())
+	assert.Equal(t, 200, pages[0].Bounds().Dy())
+	// margins are left blank, and the imageable area holds the content
+	_, _, _, a := pages[0].At(5, 5).RGBA()
+	assert.Equal(t, uint32(0), a)
+	r, _, _, a := pages[0].At(40, 40).RGBA()
+	assert.Equal(t, uint32(0xffff), a)
+	assert.Equal(t, uint32(0), r)
+}
@@ -0,0 +1,121 @@
+package print
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"io"
+
+	"fyne.io/fyne/v2"
+)
+
+// ESCPOSResolution is the resolution of ESC/POS receipt printers in dots per inch.
+const ESCPOSResolution = 203
+
+// Printable widths, in dots, of the common receipt roll sizes.
+const (
+	ESCPOSRoll58mm = 384
+	ESCPOSRoll80mm = 576
+)
+
+// escposBandHeight is the maximum number of rows sent in a single raster bit image
+// command. Some printers cannot buffer taller images.
+const escposBandHeight = 128
+
+// ESCPOSCut is the paper cut performed after each receipt.
+type ESCPOSCut int
+
+const (
+	ESCPOSNoCut      ESCPOSCut = iota // do not cut the paper
+	ESCPOSFullCut                     // cut the paper completely
+	ESCPOSPartialCut                  // cut the paper leaving one point uncut
+)
+
+// Declare conformity with PageEncoder interface
+var _ PageEncoder = (*ESCPOSEncoder)(nil)
+
+// ESCPOSEncoder writes rendered receipts as ESC/POS raster bit images.
+type ESCPOSEncoder struct {
+	Width      int
+	FeedLines  int
+	Cut        ESCPOSCut
+	OpenDrawer bool
+}
+
+// NewESCPOSEncoder creates an ESCPOSEncoder that feeds and partially cuts the
+// paper after each receipt.
+//
+// Params:
+//
+//	width is the printable width of the paper roll in dots, usually ESCPOSRoll58mm
+//	or ESCPOSRoll80mm.
+func NewESCPOSEncoder(width int) *ESCPOSEncoder {
+	return &ESCPOSEncoder{Width: width, FeedLines: 4, Cut: ESCPOSPartialCut}
+}
+
+// Format returns the MIME type of the documents created by the encoder. ESC/POS
+// documents must be sent to a raw queue or directly to the printer's device.
+func (e *ESCPOSEncoder) Format() string {
+	return RawFormat
+}
+
+// Encode writes the receipts to w. Each page image is printed at the encoder's width;
+// wider images are cropped. If OpenDrawer is set, the cash drawer is opened before
+// the first receipt is printed.
+func (e *ESCPOSEncoder) Encode(w io.Writer, pages []image.Image) error {
+	if len(pages) == 0 {
+		return errors.New("no pages to encode")
+	}
+	var buf bytes.Buffer
+	// ESC @: initialize printer
+	buf.Write([]byte{0x1b, '@'})
+	if e.OpenDrawer {
+		// ESC p m t1 t2: pulse drawer kick-out connector pin 2 for 50ms on, 500ms off
+		buf.Write([]byte{0x1b, 'p', 0, 25, 250})
+	}
+	bytesPerRow := (e.Width + 7) / 8
+	for _, page := range pages {
+		rows := monoRows(page)
+		for start := 0; start < len(rows); start += escposBandHeight {
+			end := start + escposBandHeight
+			if end > len(rows) {
+				end = len(rows)
+			}
+			// GS v 0 m xL xH yL yH: print raster bit image
+			buf.Write([]byte{0x1d, 'v', '0', 0,
+				byte(bytesPerRow), byte(bytesPerRow >> 8),
+				byte(end - start), byte((end - start) >> 8)})
+			for _, row := range rows[start:end] {
+				line := make([]byte, bytesPerRow)
+				copy(line, row)
+				buf.Write(line)
+			}
+		}
+		if e.FeedLines > 0 {
+			// ESC d n: print and feed n lines
+			buf.Write([]byte{0x1b, 'd', byte(e.FeedLines)})
+		}
+		switch e.Cut {
+		case ESCPOSFullCut:
+			// GS V m: cut paper
+			buf.Write([]byte{0x1d, 'V', 0})
+		case ESCPOSPartialCut:
+			buf.Write([]byte{0x1d, 'V', 1})
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// RenderReceipt renders a canvas object as a receipt. The object is laid out to fill
+// the width of the roll, and the length of the receipt is the object's minimum height.
+//
+// Params:
+//
+//	obj is the receipt content.
+//	width is the printable width of the paper roll in dots.
+func RenderReceipt(obj fyne.CanvasObject, width int) image.Image {
+	w := float32(width) * pointsPerInch / ESCPOSResolution
+	size := fyne.NewSize(w, obj.MinSize().Height)
+	return renderObject(obj, size, ESCPOSResolution)
+}
@@ -0,0 +1,45 @@
+package print
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestESCPOSEncoder_Encode(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 8, 130))
+	for y := 0; y < 130; y++ {
+		for x := 0; x < 8; x++ {
+			img.SetGray(x, y, color.Gray{Y: 0xff})
+		}
+	}
+	img.SetGray(0, 0, color.Gray{Y: 0})
+	e := NewESCPOSEncoder(16)
+	e.OpenDrawer = true
+	var buf bytes.Buffer
+	assert.Nil(t, e.Encode(&buf, []image.Image{img}))
+	data := buf.Bytes()
+	assert.Equal(t, []byte{0x1b, '@', 0x1b, 'p', 0, 25, 250}, data[:7])
+	// first band of 128 rows, 2 bytes per row
+	assert.Equal(t, []byte{0x1d, 'v', '0', 0, 2, 0, 128, 0, 0x80, 0}, data[7:17])
+	second := 7 + 8 + 2*128
+	assert.Equal(t, []byte{0x1d, 'v', '0', 0, 2, 0, 2, 0}, data[second:second+8])
+	assert.Equal(t, []byte{0x1b, 'd', 4, 0x1d, 'V', 1}, data[len(data)-6:])
+
+	assert.NotNil(t, e.Encode(&buf, nil))
+}
+
+func TestRenderReceipt(t *testing.T) {
+	test.NewApp()
+	rect := canvas.NewRectangle(color.Black)
+	rect.SetMinSize(fyne.NewSize(10, 72))
+	img := RenderReceipt(rect, ESCPOSRoll58mm)
+	assert.Equal(t, ESCPOSRoll58mm, img.Bounds().Dx())
+	assert.Equal(t, ESCPOSResolution, img.Bounds().Dy())
+}
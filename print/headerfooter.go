@@ -0,0 +1,108 @@
+package print
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+)
+
+// defaultDateFormat is the layout used for the {date} placeholder when a HeaderFooter
+// does not specify one.
+const defaultDateFormat = "2006-01-02"
+
+// headerFooterGap is the space, in fyne units, between a header or footer and the
+// document content.
+const headerFooterGap = 6
+
+// HeaderFooter is a template for the text printed at the top or bottom of each page.
+// The Left, Center, and Right templates may contain the placeholders {page}, {pages},
+// {date}, {title}, {printer}, and {user}.
+type HeaderFooter struct {
+	Left       string
+	Center     string
+	Right      string
+	TextSize   float32
+	DateFormat string
+}
+
+// NewHeaderFooter creates a HeaderFooter with the specified templates and a 10 point
+// text size.
+func NewHeaderFooter(left, center, right string) *HeaderFooter {
+	return &HeaderFooter{Left: left, Center: center, Right: right, TextSize: 10}
+}
+
+// pageInfo contains the values that are substituted for header and footer placeholders.
+type pageInfo struct {
+	page    int
+	pages   int
+	date    time.Time
+	title   string
+	printer string
+	user    string
+}
+
+// expand replaces the placeholders in a template with the page information.
+func (h *HeaderFooter) expand(template string, info pageInfo) string {
+	return info.expand(template, h.DateFormat)
+}
+
+// expand replaces the placeholders in a template with the page information. The
+// {date} placeholder is formatted using dateFormat, or defaultDateFormat if it is empty.
+func (info pageInfo) expand(template, dateFormat string) string {
+	if dateFormat == "" {
+		dateFormat = defaultDateFormat
+	}
+	r := strings.NewReplacer(
+		"{page}", strconv.Itoa(info.page),
+		"{pages}", strconv.Itoa(info.pages),
+		"{date}", info.date.Format(dateFormat),
+		"{title}", info.title,
+		"{printer}", info.printer,
+		"{user}", info.user)
+	return r.Replace(template)
+}
+
+// height returns the height of the band that the header or footer is printed in,
+// including the gap between it and the document content.
+func (h *HeaderFooter) height() float32 {
+	if h == nil {
+		return 0
+	}
+	t := canvas.NewText("Xg", color.Black)
+	t.TextSize = h.TextSize
+	return t.MinSize().Height + headerFooterGap
+}
+
+// band creates the object that displays the header or footer for a page.
+//
+// Params:
+//
+//	width is the width of the band.
+//	info contains the placeholder values for the page.
+func (h *HeaderFooter) band(width float32, info pageInfo) *fyne.Container {
+	band := container.NewWithoutLayout()
+	for i, tmpl := range []string{h.Left, h.Center, h.Right} {
+		if tmpl == "" {
+			continue
+		}
+		t := canvas.NewText(h.expand(tmpl, info), color.Black)
+		t.TextSize = h.TextSize
+		t.Resize(t.MinSize())
+		x := float32(0)
+		switch i {
+		case 1:
+			x = (width - t.MinSize().Width) / 2
+		case 2:
+			x = width - t.MinSize().Width
+		}
+		t.Move(fyne.NewPos(x, 0))
+		band.Add(t)
+	}
+	band.Resize(fyne.NewSize(width, h.height()-headerFooterGap))
+	return band
+}
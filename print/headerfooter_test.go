@@ -0,0 +1,60 @@
+package print
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaderFooter_Expand(t *testing.T) {
+	h := NewHeaderFooter("", "", "")
+	info := pageInfo{page: 2, pages: 5, date: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC),
+		title: "Report", printer: "Office"}
+	assert.Equal(t, "Page 2 of 5", h.expand("Page {page} of {pages}", info))
+	assert.Equal(t, "Report on Office, 2025-03-04", h.expand("{title} on {printer}, {date}", info))
+	h.DateFormat = "Jan 2, 2006"
+	assert.Equal(t, "Mar 4, 2025", h.expand("{date}", info))
+}
+
+func TestHeaderFooter_Height(t *testing.T) {
+	test.NewApp()
+	var h *HeaderFooter
+	assert.Equal(t, float32(0), h.height())
+	h = NewHeaderFooter("{title}", "", "")
+	assert.Greater(t, h.height(), float32(headerFooterGap))
+}
+
+func TestPrintOperation_RenderPagesWithHeaderFooter(t *testing.T) {
+	test.NewApp()
+	pc := newPrintContext(fyne.NewSize(200, 200), Margins{top: 10, bottom: 10, left: 10, right: 10}, 72)
+	doc := NewDocument("Report")
+	doc.Add(newTestRect(150))
+	po := &PrintOperation{}
+	po.SetDocument(doc)
+	pages, err := po.RenderPages(pc, "Office")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(pages))
+
+	po.SetHeader(NewHeaderFooter("{title}", "", ""))
+	po.SetFooter(NewHeaderFooter("", "Page {page} of {pages}", ""))
+	pages, err = po.RenderPages(pc, "Office")
+	assert.Nil(t, err)
+	// the header and footer reduce the height available to the document
+	assert.Equal(t, 2, len(pages))
+	// the header is drawn at the top of the imageable area of the second page
+	drawn := false
+	for x := 10; x < 190 && !drawn; x++ {
+		for y := 10; y < 20; y++ {
+			if _, _, _, a := pages[1].At(x, y).RGBA(); a != 0 {
+				drawn = true
+			}
+		}
+	}
+	assert.True(t, drawn)
+
+	_, err = (&PrintOperation{}).RenderPages(pc, "Office")
+	assert.NotNil(t, err)
+}
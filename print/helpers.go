@@ -2,6 +2,7 @@ package print
 
 import (
 	"fmt"
+	"os/user"
 	"strings"
 )
 
@@ -14,3 +15,12 @@ func prepend(prepend, text string) string {
 	}
 	return s.String()
 }
+
+// currentUserName returns the login name of the user running the program, or an empty
+// string if it cannot be determined.
+func currentUserName() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
@@ -0,0 +1,598 @@
+package print
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"os"
+)
+
+// RenderingIntent is the ICC rendering intent used to map colors that the printer
+// cannot reproduce. The values match those in the ICC profile header.
+type RenderingIntent int
+
+const (
+	// IntentPerceptual compresses the whole gamut to preserve the relationship between
+	// colors. It matches the Windows DMICM_CONTRAST intent.
+	IntentPerceptual RenderingIntent = iota
+	// IntentRelativeColorimetric reproduces in-gamut colors exactly relative to the
+	// paper white. It matches the Windows DMICM_COLORIMETRIC intent.
+	IntentRelativeColorimetric
+	// IntentSaturation preserves the saturation of colors. It matches the Windows
+	// DMICM_SATURATE intent.
+	IntentSaturation
+	// IntentAbsoluteColorimetric reproduces in-gamut colors exactly, including the
+	// color of the paper. It matches the Windows DMICM_ABS_COLORIMETRIC intent.
+	IntentAbsoluteColorimetric
+)
+
+// renderingIntentNames are the IPP "print-rendering-intent" keywords for the
+// RenderingIntent values.
+var renderingIntentNames = []string{"perceptual", "relative", "saturation", "absolute"}
+
+// String returns the IPP keyword for the rendering intent.
+func (ri RenderingIntent) String() string {
+	if ri < 0 || int(ri) >= len(renderingIntentNames) {
+		return renderingIntentNames[IntentPerceptual]
+	}
+	return renderingIntentNames[ri]
+}
+
+// ParseRenderingIntent converts an IPP "print-rendering-intent" keyword to a
+// RenderingIntent value. The "auto" keyword selects the perceptual intent and
+// "relative-bpc" selects the relative colorimetric intent.
+func ParseRenderingIntent(keyword string) (RenderingIntent, error) {
+	switch keyword {
+	case "auto":
+		return IntentPerceptual, nil
+	case "relative-bpc":
+		return IntentRelativeColorimetric, nil
+	}
+	for i, name := range renderingIntentNames {
+		if name == keyword {
+			return RenderingIntent(i), nil
+		}
+	}
+	return IntentPerceptual, fmt.Errorf("unknown print-rendering-intent value: %s", keyword)
+}
+
+// d50 is the ICC profile connection space illuminant.
+var d50 = [3]float64{0.9642, 1.0, 0.8249}
+
+// srgbToXYZ converts linear sRGB values to D50 XYZ using the Bradford-adapted sRGB
+// primaries.
+var srgbToXYZ = [3][3]float64{
+	{0.4360747, 0.3850649, 0.1430804},
+	{0.2225045, 0.7168786, 0.0606169},
+	{0.0139322, 0.0971045, 0.7141733},
+}
+
+// iccCurve is a one-dimensional tone reproduction curve that maps values from 0 to 1.
+type iccCurve func(float64) float64
+
+// inverse returns the input value that the monotonically increasing curve maps to v.
+func (c iccCurve) inverse(v float64) float64 {
+	lo, hi := 0.0, 1.0
+	for i := 0; i < 24; i++ {
+		mid := (lo + hi) / 2
+		if c(mid) < v {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// iccLut is an lut8Type or lut16Type transform from the profile connection space to
+// device values.
+type iccLut struct {
+	lut8            bool
+	inChan, outChan int
+	grid            int
+	matrix          [3][3]float64
+	inCurves        [][]float64
+	clut            []float64
+	outCurves       [][]float64
+}
+
+// ICCProfile is an ICC output profile for RGB or grayscale devices. Profiles with
+// lut8Type or lut16Type BToA tags, or with matrix and tone reproduction curve tags, are
+// supported.
+type ICCProfile struct {
+	gray       bool
+	labPCS     bool
+	whitePoint [3]float64
+	// luts are the BToA0, BToA1 and BToA2 transforms, indexed by rendering intent.
+	luts [3]*iccLut
+	// matrix and curves are the device to PCS transform of matrix/TRC profiles.
+	matrix [3][3]float64
+	curves [3]iccCurve
+}
+
+// LoadICCProfile reads and parses an ICC profile file.
+func LoadICCProfile(path string) (*ICCProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseICCProfile(data)
+}
+
+// ParseICCProfile parses the data of an ICC output profile.
+func ParseICCProfile(data []byte) (*ICCProfile, error) {
+	if len(data) < 132 || string(data[36:40]) != "acsp" {
+		return nil, errors.New("not an ICC profile")
+	}
+	p := &ICCProfile{whitePoint: d50}
+	switch string(data[16:20]) {
+	case "RGB ":
+	case "GRAY":
+		p.gray = true
+	default:
+		return nil, fmt.Errorf("unsupported ICC profile color space: %q", data[16:20])
+	}
+	p.labPCS = string(data[20:24]) == "Lab "
+
+	tags := map[string][]byte{}
+	count := int(binary.BigEndian.Uint32(data[128:]))
+	for i := 0; i < count; i++ {
+		entry := 132 + i*12
+		if entry+12 > len(data) {
+			return nil, errors.New("truncated ICC tag table")
+		}
+		offset := int(binary.BigEndian.Uint32(data[entry+4:]))
+		size := int(binary.BigEndian.Uint32(data[entry+8:]))
+		if offset+size > len(data) || size < 8 {
+			return nil, errors.New("ICC tag is outside of the profile")
+		}
+		tags[string(data[entry:entry+4])] = data[offset : offset+size]
+	}
+
+	if wtpt, ok := tags["wtpt"]; ok {
+		xyz, err := parseXYZTag(wtpt)
+		if err != nil {
+			return nil, err
+		}
+		p.whitePoint = xyz
+	}
+	outChan := 3
+	if p.gray {
+		outChan = 1
+	}
+	for i, sig := range []string{"B2A0", "B2A1", "B2A2"} {
+		tag, ok := tags[sig]
+		if !ok {
+			continue
+		}
+		lut, err := parseLutTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", sig, err)
+		}
+		if lut.inChan != 3 || lut.outChan != outChan {
+			return nil, fmt.Errorf("%s has %d input and %d output channels", sig,
+				lut.inChan, lut.outChan)
+		}
+		p.luts[i] = lut
+	}
+	if p.luts[0] != nil || p.luts[1] != nil || p.luts[2] != nil {
+		return p, nil
+	}
+	return p, p.parseMatrixTRC(tags)
+}
+
+// parseMatrixTRC parses the colorant and tone reproduction curve tags of a matrix/TRC
+// RGB profile, or the gray tone reproduction curve of a grayscale profile.
+func (p *ICCProfile) parseMatrixTRC(tags map[string][]byte) error {
+	trcs := []string{"rTRC", "gTRC", "bTRC"}
+	if p.gray {
+		trcs = []string{"kTRC"}
+	}
+	for i, sig := range trcs {
+		tag, ok := tags[sig]
+		if !ok {
+			return fmt.Errorf("ICC profile has no BToA or %s tag", sig)
+		}
+		curve, err := parseCurveTag(tag)
+		if err != nil {
+			return fmt.Errorf("%s: %w", sig, err)
+		}
+		p.curves[i] = curve
+	}
+	if p.gray {
+		return nil
+	}
+	for i, sig := range []string{"rXYZ", "gXYZ", "bXYZ"} {
+		tag, ok := tags[sig]
+		if !ok {
+			return fmt.Errorf("ICC profile has no BToA or %s tag", sig)
+		}
+		xyz, err := parseXYZTag(tag)
+		if err != nil {
+			return fmt.Errorf("%s: %w", sig, err)
+		}
+		for row := 0; row < 3; row++ {
+			p.matrix[row][i] = xyz[row]
+		}
+	}
+	return nil
+}
+
+// s15Fixed16 converts a signed 15.16 fixed point number to a float.
+func s15Fixed16(b []byte) float64 {
+	return float64(int32(binary.BigEndian.Uint32(b))) / 65536
+}
+
+// parseXYZTag parses the first value of an XYZType tag.
+func parseXYZTag(tag []byte) ([3]float64, error) {
+	if string(tag[:4]) != "XYZ " || len(tag) < 20 {
+		return [3]float64{}, errors.New("invalid XYZ tag")
+	}
+	return [3]float64{s15Fixed16(tag[8:]), s15Fixed16(tag[12:]), s15Fixed16(tag[16:])}, nil
+}
+
+// parseCurveTag parses a curveType or parametricCurveType tag.
+func parseCurveTag(tag []byte) (iccCurve, error) {
+	switch string(tag[:4]) {
+	case "curv":
+		if len(tag) < 12 {
+			break
+		}
+		n := int(binary.BigEndian.Uint32(tag[8:]))
+		switch {
+		case n == 0:
+			return func(x float64) float64 { return x }, nil
+		case n == 1 && len(tag) >= 14:
+			gamma := float64(binary.BigEndian.Uint16(tag[12:])) / 256
+			return func(x float64) float64 { return math.Pow(x, gamma) }, nil
+		case len(tag) >= 12+2*n:
+			table := make([]float64, n)
+			for i := range table {
+				table[i] = float64(binary.BigEndian.Uint16(tag[12+2*i:])) / 0xffff
+			}
+			return func(x float64) float64 { return interpolate(table, x) }, nil
+		}
+	case "para":
+		if len(tag) < 12 {
+			break
+		}
+		fn := binary.BigEndian.Uint16(tag[8:])
+		counts := []int{1, 3, 4, 5, 7}
+		if int(fn) >= len(counts) || len(tag) < 12+4*counts[fn] {
+			break
+		}
+		var g [7]float64
+		for i := 0; i < counts[fn]; i++ {
+			g[i] = s15Fixed16(tag[12+4*i:])
+		}
+		return parametricCurve(fn, g), nil
+	}
+	return nil, errors.New("invalid curve tag")
+}
+
+// parametricCurve returns the curve for a parametricCurveType function and its
+// gamma, a, b, c, d, e and f parameters.
+func parametricCurve(fn uint16, g [7]float64) iccCurve {
+	gamma, a, b, c, d, e, f := g[0], g[1], g[2], g[3], g[4], g[5], g[6]
+	pow := func(x float64) float64 {
+		if x <= 0 {
+			return 0
+		}
+		return math.Pow(x, gamma)
+	}
+	switch fn {
+	case 1:
+		return func(x float64) float64 {
+			if x >= -b/a {
+				return pow(a*x + b)
+			}
+			return 0
+		}
+	case 2:
+		return func(x float64) float64 {
+			if x >= -b/a {
+				return pow(a*x+b) + c
+			}
+			return c
+		}
+	case 3:
+		return func(x float64) float64 {
+			if x >= d {
+				return pow(a*x + b)
+			}
+			return c * x
+		}
+	case 4:
+		return func(x float64) float64 {
+			if x >= d {
+				return pow(a*x+b) + e
+			}
+			return c*x + f
+		}
+	}
+	return func(x float64) float64 { return pow(x) }
+}
+
+// parseLutTag parses an lut8Type or lut16Type tag.
+func parseLutTag(tag []byte) (*iccLut, error) {
+	if len(tag) < 48 {
+		return nil, errors.New("invalid lut tag")
+	}
+	lut := &iccLut{inChan: int(tag[8]), outChan: int(tag[9]), grid: int(tag[10])}
+	for i := 0; i < 9; i++ {
+		lut.matrix[i/3][i%3] = s15Fixed16(tag[12+4*i:])
+	}
+	var read func(int) float64
+	var inEntries, outEntries, pos int
+	switch string(tag[:4]) {
+	case "mft1":
+		lut.lut8 = true
+		inEntries, outEntries, pos = 256, 256, 48
+		read = func(i int) float64 { return float64(tag[i]) / 0xff }
+	case "mft2":
+		if len(tag) < 52 {
+			return nil, errors.New("invalid lut16 tag")
+		}
+		inEntries = int(binary.BigEndian.Uint16(tag[48:]))
+		outEntries = int(binary.BigEndian.Uint16(tag[50:]))
+		pos = 52
+		read = func(i int) float64 { return float64(binary.BigEndian.Uint16(tag[i:])) / 0xffff }
+	default:
+		return nil, fmt.Errorf("unsupported lut tag type: %q", tag[:4])
+	}
+	// Only lookups from the three channel profile connection space are used, and the
+	// limits keep the table sizes of a malformed profile from overflowing.
+	if lut.inChan != 3 || lut.outChan < 1 || lut.outChan > 15 || lut.grid < 2 ||
+		inEntries < 2 || outEntries < 2 {
+		return nil, fmt.Errorf("unsupported lut tag: %d input channels, %d output channels, "+
+			"%d grid points, %d input and %d output table entries", lut.inChan, lut.outChan,
+			lut.grid, inEntries, outEntries)
+	}
+	size := 2
+	if lut.lut8 {
+		size = 1
+	}
+	clutLen := lut.outChan
+	for i := 0; i < lut.inChan; i++ {
+		clutLen *= lut.grid
+	}
+	if len(tag) < pos+size*(lut.inChan*inEntries+clutLen+
+		lut.outChan*outEntries) {
+		return nil, errors.New("truncated lut tag")
+	}
+	table := func(n int) []float64 {
+		t := make([]float64, n)
+		for i := range t {
+			t[i] = read(pos)
+			pos += size
+		}
+		return t
+	}
+	for i := 0; i < lut.inChan; i++ {
+		lut.inCurves = append(lut.inCurves, table(inEntries))
+	}
+	lut.clut = table(clutLen)
+	for i := 0; i < lut.outChan; i++ {
+		lut.outCurves = append(lut.outCurves, table(outEntries))
+	}
+	return lut, nil
+}
+
+// interpolate returns the linearly interpolated value of a table at x between 0 and 1.
+func interpolate(table []float64, x float64) float64 {
+	if len(table) == 1 {
+		return table[0]
+	}
+	pos := clamp01(x) * float64(len(table)-1)
+	i := int(pos)
+	if i >= len(table)-1 {
+		return table[len(table)-1]
+	}
+	frac := pos - float64(i)
+	return table[i] + (table[i+1]-table[i])*frac
+}
+
+// clamp01 limits v to the range 0 to 1.
+func clamp01(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// eval applies the lut to three input values between 0 and 1.
+func (l *iccLut) eval(in [3]float64) []float64 {
+	var x [3]float64
+	for i := range x {
+		x[i] = interpolate(l.inCurves[i], in[i])
+	}
+	// trilinear interpolation of the color lookup table
+	var base [3]int
+	var frac [3]float64
+	for i := range x {
+		pos := x[i] * float64(l.grid-1)
+		base[i] = int(pos)
+		if base[i] >= l.grid-1 {
+			base[i] = l.grid - 2
+		}
+		frac[i] = pos - float64(base[i])
+	}
+	out := make([]float64, l.outChan)
+	for corner := 0; corner < 8; corner++ {
+		weight := 1.0
+		index := 0
+		for i := 0; i < 3; i++ {
+			g := base[i]
+			if corner&(4>>i) != 0 {
+				g++
+				weight *= frac[i]
+			} else {
+				weight *= 1 - frac[i]
+			}
+			index = index*l.grid + g
+		}
+		for o := range out {
+			out[o] += weight * l.clut[index*l.outChan+o]
+		}
+	}
+	for o := range out {
+		out[o] = interpolate(l.outCurves[o], out[o])
+	}
+	return out
+}
+
+// toDevice converts a D50 XYZ color in the profile connection space to device values
+// between 0 and 1. Unknown intents use the perceptual intent.
+func (p *ICCProfile) toDevice(xyz [3]float64, intent RenderingIntent) []float64 {
+	if intent < IntentPerceptual || intent > IntentAbsoluteColorimetric {
+		intent = IntentPerceptual
+	}
+	if intent == IntentAbsoluteColorimetric {
+		for i := range xyz {
+			xyz[i] *= d50[i] / p.whitePoint[i]
+		}
+		intent = IntentRelativeColorimetric
+	}
+	lut := p.luts[intent]
+	if lut == nil {
+		lut = p.luts[IntentPerceptual]
+	}
+	if lut == nil {
+		lut = p.luts[IntentRelativeColorimetric]
+	}
+	if lut != nil {
+		return lut.eval(p.encodePCS(xyz, lut))
+	}
+	if p.gray {
+		return []float64{p.curves[0].inverse(clamp01(xyz[1]))}
+	}
+	rgb := solve3(p.matrix, xyz)
+	out := make([]float64, 3)
+	for i := range out {
+		out[i] = p.curves[i].inverse(clamp01(rgb[i]))
+	}
+	return out
+}
+
+// encodePCS converts a D50 XYZ color to the normalized lut input values of the
+// profile connection space.
+func (p *ICCProfile) encodePCS(xyz [3]float64, lut *iccLut) [3]float64 {
+	if !p.labPCS {
+		var in [3]float64
+		for i := range xyz {
+			xyz[i] /= 65535.0 / 32768
+		}
+		for row := 0; row < 3; row++ {
+			for col := 0; col < 3; col++ {
+				in[row] += lut.matrix[row][col] * xyz[col]
+			}
+		}
+		return in
+	}
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(xyz[0]/d50[0]), f(xyz[1]/d50[1]), f(xyz[2]/d50[2])
+	l, a, b := 116*fy-16, 500*(fx-fy), 200*(fy-fz)
+	if lut.lut8 {
+		return [3]float64{l / 100, (a + 128) / 255, (b + 128) / 255}
+	}
+	// lut16Type uses the legacy 16-bit Lab encoding with L* = 100 at 0xff00
+	return [3]float64{l / 100 * 0xff00 / 0xffff, (a + 128) * 256 / 0xffff,
+		(b + 128) * 256 / 0xffff}
+}
+
+// solve3 returns the vector v such that m * v = y.
+func solve3(m [3][3]float64, y [3]float64) [3]float64 {
+	det := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+	if det == 0 {
+		return [3]float64{}
+	}
+	var v [3]float64
+	for i := 0; i < 3; i++ {
+		mi := m
+		for row := 0; row < 3; row++ {
+			mi[row][i] = y[row]
+		}
+		v[i] = (mi[0][0]*(mi[1][1]*mi[2][2]-mi[1][2]*mi[2][1]) -
+			mi[0][1]*(mi[1][0]*mi[2][2]-mi[1][2]*mi[2][0]) +
+			mi[0][2]*(mi[1][0]*mi[2][1]-mi[1][1]*mi[2][0])) / det
+	}
+	return v
+}
+
+// srgbToPCS converts an 8-bit sRGB color to D50 XYZ.
+func srgbToPCS(r, g, b uint8) [3]float64 {
+	linear := func(v uint8) float64 {
+		c := float64(v) / 0xff
+		if c <= 0.04045 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	rgb := [3]float64{linear(r), linear(g), linear(b)}
+	var xyz [3]float64
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			xyz[row] += srgbToXYZ[row][col] * rgb[col]
+		}
+	}
+	return xyz
+}
+
+// ColorManagement describes the conversion of rendered sRGB pages to the color space
+// of an output device.
+type ColorManagement struct {
+	// Profile is the output profile of the printer, or a profile supplied by the user.
+	Profile *ICCProfile
+	Intent  RenderingIntent
+}
+
+// Apply converts each page from sRGB to the output profile. Transparent pixels are
+// composited onto white paper. Pages are returned unchanged if there is no profile.
+func (cm ColorManagement) Apply(pages []image.Image) []image.Image {
+	if cm.Profile == nil {
+		return pages
+	}
+	cache := map[[3]uint8]color.Color{}
+	converted := make([]image.Image, len(pages))
+	for i, page := range pages {
+		b := page.Bounds()
+		var dst draw.Image
+		if cm.Profile.gray {
+			dst = image.NewGray(b)
+		} else {
+			dst = image.NewNRGBA(b)
+		}
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				r, g, bl := onWhite(page.At(x, y))
+				key := [3]uint8{r, g, bl}
+				c, ok := cache[key]
+				if !ok {
+					c = cm.convert(r, g, bl)
+					cache[key] = c
+				}
+				dst.Set(x, y, c)
+			}
+		}
+		converted[i] = dst
+	}
+	return converted
+}
+
+// convert converts an 8-bit sRGB color to the output profile's device color.
+func (cm ColorManagement) convert(r, g, b uint8) color.Color {
+	out := cm.Profile.toDevice(srgbToPCS(r, g, b), cm.Intent)
+	to8 := func(v float64) uint8 { return uint8(math.Round(clamp01(v) * 0xff)) }
+	if cm.Profile.gray {
+		return color.Gray{Y: to8(out[0])}
+	}
+	return color.NRGBA{R: to8(out[0]), G: to8(out[1]), B: to8(out[2]), A: 0xff}
+}
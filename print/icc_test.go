@@ -0,0 +1,168 @@
+package print
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// iccTag is a tag that is written into a test ICC profile.
+type iccTag struct {
+	sig  string
+	data []byte
+}
+
+// newTestICCProfile builds the data of an ICC profile with the specified tags.
+func newTestICCProfile(colorSpace, pcs string, tags ...iccTag) []byte {
+	data := make([]byte, 132+12*len(tags))
+	copy(data[16:], colorSpace)
+	copy(data[20:], pcs)
+	copy(data[36:], "acsp")
+	binary.BigEndian.PutUint32(data[128:], uint32(len(tags)))
+	for i, tag := range tags {
+		entry := data[132+12*i:]
+		copy(entry, tag.sig)
+		binary.BigEndian.PutUint32(entry[4:], uint32(len(data)))
+		binary.BigEndian.PutUint32(entry[8:], uint32(len(tag.data)))
+		data = append(data, tag.data...)
+	}
+	binary.BigEndian.PutUint32(data, uint32(len(data)))
+	return data
+}
+
+func fixed(v float64) []byte {
+	return binary.BigEndian.AppendUint32(nil, uint32(int32(v*65536)))
+}
+
+func xyzTag(x, y, z float64) []byte {
+	data := append([]byte("XYZ \x00\x00\x00\x00"), fixed(x)...)
+	data = append(data, fixed(y)...)
+	return append(data, fixed(z)...)
+}
+
+func srgbCurveTag() []byte {
+	data := []byte("para\x00\x00\x00\x00\x00\x03\x00\x00")
+	for _, v := range []float64{2.4, 1 / 1.055, 0.055 / 1.055, 1 / 12.92, 0.04045} {
+		data = append(data, fixed(v)...)
+	}
+	return data
+}
+
+func TestParseICCProfile_Errors(t *testing.T) {
+	_, err := ParseICCProfile([]byte("not a profile"))
+	assert.NotNil(t, err)
+	_, err = ParseICCProfile(newTestICCProfile("CMYK", "Lab "))
+	assert.NotNil(t, err)
+	_, err = ParseICCProfile(newTestICCProfile("RGB ", "XYZ "))
+	assert.NotNil(t, err)
+}
+
+func TestColorManagement_MatrixTRC(t *testing.T) {
+	var tags []iccTag
+	for i, sig := range []string{"rXYZ", "gXYZ", "bXYZ"} {
+		tags = append(tags, iccTag{sig, xyzTag(srgbToXYZ[0][i], srgbToXYZ[1][i],
+			srgbToXYZ[2][i])})
+	}
+	for _, sig := range []string{"rTRC", "gTRC", "bTRC"} {
+		tags = append(tags, iccTag{sig, srgbCurveTag()})
+	}
+	profile, err := ParseICCProfile(newTestICCProfile("RGB ", "XYZ ", tags...))
+	assert.Nil(t, err)
+
+	cm := ColorManagement{Profile: profile, Intent: IntentRelativeColorimetric}
+	page := newTestPage(1, 1, color.NRGBA{R: 200, G: 100, B: 50, A: 0xff})
+	c := cm.Apply([]image.Image{page})[0].At(0, 0).(color.NRGBA)
+	assert.InDelta(t, 200, int(c.R), 1)
+	assert.InDelta(t, 100, int(c.G), 1)
+	assert.InDelta(t, 50, int(c.B), 1)
+}
+
+func TestColorManagement_Gray(t *testing.T) {
+	profile, err := ParseICCProfile(newTestICCProfile("GRAY", "XYZ ",
+		iccTag{"kTRC", []byte("curv\x00\x00\x00\x00\x00\x00\x00\x00")}))
+	assert.Nil(t, err)
+
+	cm := ColorManagement{Profile: profile}
+	pages := cm.Apply([]image.Image{newTestPage(1, 1, color.White),
+		newTestPage(1, 1, color.Gray{Y: 128})})
+	assert.Equal(t, color.Gray{Y: 0xff}, pages[0].At(0, 0))
+	assert.Equal(t, color.Gray{Y: 55}, pages[1].At(0, 0))
+}
+
+func TestColorManagement_Lut16(t *testing.T) {
+	// a 2x2x2 grid that outputs L* in every channel
+	lut := []byte("mft2\x00\x00\x00\x00\x03\x03\x02\x00")
+	for i := 0; i < 9; i++ {
+		identity := 0.0
+		if i%4 == 0 {
+			identity = 1
+		}
+		lut = append(lut, fixed(identity)...)
+	}
+	lut = binary.BigEndian.AppendUint16(lut, 2)
+	lut = binary.BigEndian.AppendUint16(lut, 2)
+	for i := 0; i < 3; i++ {
+		lut = binary.BigEndian.AppendUint16(lut, 0)
+		lut = binary.BigEndian.AppendUint16(lut, 0xffff)
+	}
+	for l := 0; l < 2; l++ {
+		for ab := 0; ab < 4; ab++ {
+			for o := 0; o < 3; o++ {
+				lut = binary.BigEndian.AppendUint16(lut, uint16(l*0xffff))
+			}
+		}
+	}
+	for i := 0; i < 3; i++ {
+		lut = binary.BigEndian.AppendUint16(lut, 0)
+		lut = binary.BigEndian.AppendUint16(lut, 0xffff)
+	}
+	profile, err := ParseICCProfile(newTestICCProfile("RGB ", "Lab ", iccTag{"B2A0", lut}))
+	assert.Nil(t, err)
+
+	cm := ColorManagement{Profile: profile, Intent: IntentSaturation}
+	pages := cm.Apply([]image.Image{newTestPage(1, 1, color.White),
+		newTestPage(1, 1, color.Black)})
+	assert.Equal(t, color.NRGBA{R: 254, G: 254, B: 254, A: 0xff}, pages[0].At(0, 0))
+	assert.Equal(t, color.NRGBA{A: 0xff}, pages[1].At(0, 0))
+}
+
+func TestParseLutTag_Malformed(t *testing.T) {
+	lut16 := func(inChan, outChan, grid byte, inEntries, outEntries uint16) []byte {
+		tag := append([]byte("mft2\x00\x00\x00\x00"), inChan, outChan, grid, 0)
+		tag = append(tag, make([]byte, 36)...)
+		tag = binary.BigEndian.AppendUint16(tag, inEntries)
+		tag = binary.BigEndian.AppendUint16(tag, outEntries)
+		return append(tag, make([]byte, 1024)...)
+	}
+	_, err := parseLutTag(lut16(3, 3, 2, 0, 0))
+	assert.NotNil(t, err)
+	_, err = parseLutTag(lut16(255, 255, 255, 2, 2))
+	assert.NotNil(t, err)
+	_, err = parseLutTag(lut16(3, 0, 2, 2, 2))
+	assert.NotNil(t, err)
+	_, err = parseLutTag(lut16(3, 3, 255, 2, 2))
+	assert.NotNil(t, err)
+}
+
+func TestColorManagement_UnknownIntent(t *testing.T) {
+	profile, err := ParseICCProfile(newTestICCProfile("GRAY", "XYZ ",
+		iccTag{"kTRC", []byte("curv\x00\x00\x00\x00\x00\x00\x00\x00")}))
+	assert.Nil(t, err)
+	cm := ColorManagement{Profile: profile, Intent: RenderingIntent(7)}
+	pages := cm.Apply([]image.Image{newTestPage(1, 1, color.White)})
+	assert.Equal(t, color.Gray{Y: 0xff}, pages[0].At(0, 0))
+}
+
+func TestParseRenderingIntent(t *testing.T) {
+	for _, ri := range []RenderingIntent{IntentPerceptual, IntentRelativeColorimetric,
+		IntentSaturation, IntentAbsoluteColorimetric} {
+		parsed, err := ParseRenderingIntent(ri.String())
+		assert.Nil(t, err)
+		assert.Equal(t, ri, parsed)
+	}
+	_, err := ParseRenderingIntent("vivid")
+	assert.NotNil(t, err)
+}
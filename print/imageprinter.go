@@ -0,0 +1,163 @@
+package print
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// PNGFormat is the MIME type of PNG images.
+const PNGFormat = "image/png"
+
+// ImageFormat is the file format that an image printer saves pages in.
+type ImageFormat int
+
+const (
+	ImagePNG  ImageFormat = iota // one PNG file for each page
+	ImageTIFF                    // one multi-page TIFF file for the job
+)
+
+// pngSignature is the first eight bytes of every PNG image.
+const pngSignature = "\x89PNG\r\n\x1a\n"
+
+// PNGEncoder writes each page as a PNG image. The images are written one after the
+// other, so a document with more than one page is a sequence of PNG images.
+type PNGEncoder struct {
+	// Resolution is the resolution of the rendered pages in dots per inch. It is
+	// recorded in each image so that the pages have the correct physical size.
+	Resolution int
+}
+
+// Format returns the MIME type of PNG images.
+func (e *PNGEncoder) Format() string {
+	return PNGFormat
+}
+
+// Encode writes the pages to w as a sequence of PNG images.
+func (e *PNGEncoder) Encode(w io.Writer, pages []image.Image) error {
+	for _, page := range pages {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, page); err != nil {
+			return err
+		}
+		if _, err := w.Write(e.withResolution(buf.Bytes())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withResolution inserts a pHYs chunk that records the resolution after the IHDR chunk
+// of a PNG image.
+func (e *PNGEncoder) withResolution(img []byte) []byte {
+	if e.Resolution <= 0 {
+		return img
+	}
+	// the IHDR chunk always has 13 bytes of data
+	ihdrEnd := len(pngSignature) + 12 + 13
+	pixelsPerMeter := uint32(float64(e.Resolution)/0.0254 + 0.5)
+	chunk := []byte("pHYs")
+	chunk = binary.BigEndian.AppendUint32(chunk, pixelsPerMeter)
+	chunk = binary.BigEndian.AppendUint32(chunk, pixelsPerMeter)
+	chunk = append(chunk, 1) // the unit is the meter
+	phys := binary.BigEndian.AppendUint32(nil, uint32(len(chunk)-4))
+	phys = append(phys, chunk...)
+	phys = binary.BigEndian.AppendUint32(phys, crc32.ChecksumIEEE(chunk))
+
+	withPhys := make([]byte, 0, len(img)+len(phys))
+	withPhys = append(withPhys, img[:ihdrEnd]...)
+	withPhys = append(withPhys, phys...)
+	return append(withPhys, img[ihdrEnd:]...)
+}
+
+// splitPNGs splits a sequence of PNG images written by PNGEncoder into the separate
+// images.
+func splitPNGs(data []byte) ([][]byte, error) {
+	var images [][]byte
+	for len(data) > 0 {
+		if !bytes.HasPrefix(data, []byte(pngSignature)) {
+			return nil, errors.New("invalid PNG image")
+		}
+		pos := len(pngSignature)
+		for {
+			if pos+12 > len(data) {
+				return nil, errors.New("truncated PNG image")
+			}
+			length := int(binary.BigEndian.Uint32(data[pos:]))
+			chunkType := string(data[pos+4 : pos+8])
+			pos += 12 + length
+			if chunkType == "IEND" {
+				break
+			}
+		}
+		if pos > len(data) {
+			return nil, errors.New("truncated PNG image")
+		}
+		images = append(images, data[:pos])
+		data = data[pos:]
+	}
+	return images, nil
+}
+
+// fileTitle returns a job title that can be used as a file name. Path separators, and
+// the colons that Windows uses for drive names, are replaced with underscores. A title
+// that does not name a file is replaced with "untitled".
+func fileTitle(title string) string {
+	title = strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(title)
+	title = filepath.Base(title)
+	if title == "." || title == ".." || title == string(filepath.Separator) {
+		return "untitled"
+	}
+	return title
+}
+
+// NewImagePrinter creates a virtual printer that saves the rendered pages of each job
+// as image files in a directory. PNG files are named after the job title and the page
+// number, for example "Report-1.png", and TIFF files are named after the job title.
+// Path separators and colons in the title are replaced, so the files are always saved
+// in the directory.
+//
+// Params:
+//
+//	name is the printer name.
+//	format is the image file format.
+//	dpi is the resolution that pages are rendered at. Zero uses the resolution of the
+//	print context.
+//	dir is the directory that the images are saved in.
+func NewImagePrinter(name string, format ImageFormat, dpi int, dir string) *Printer {
+	v := &virtualPrinter{name: name, dpi: dpi}
+	switch format {
+	case ImageTIFF:
+		v.newEncoder = func(dpi int) PageEncoder { return &TIFFEncoder{Resolution: dpi} }
+		v.write = func(title string, document io.Reader) error {
+			return SendToDevice(filepath.Join(dir, fileTitle(title)+".tiff"), document)
+		}
+	default:
+		v.newEncoder = func(dpi int) PageEncoder { return &PNGEncoder{Resolution: dpi} }
+		v.write = func(title string, document io.Reader) error {
+			data, err := io.ReadAll(document)
+			if err != nil {
+				return err
+			}
+			images, err := splitPNGs(data)
+			if err != nil {
+				return err
+			}
+			for i, img := range images {
+				path := filepath.Join(dir, fmt.Sprintf("%s-%d.png", fileTitle(title), i+1))
+				if err = SendToDevice(path, bytes.NewReader(img)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return newVirtualPrinter(v)
+}
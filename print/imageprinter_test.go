@@ -0,0 +1,54 @@
+package print
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPNGEncoder_Encode(t *testing.T) {
+	pages := []image.Image{newTestPage(3, 2, color.Black), newTestPage(4, 4, color.White)}
+	var buf bytes.Buffer
+	e := &PNGEncoder{Resolution: 300}
+	assert.Equal(t, PNGFormat, e.Format())
+	assert.Nil(t, e.Encode(&buf, pages))
+
+	images, err := splitPNGs(buf.Bytes())
+	assert.Nil(t, err)
+	assert.Len(t, images, 2)
+	assert.Contains(t, string(images[0]), "pHYs\x00\x00\x2e\x23")
+	for i, data := range images {
+		img, err := png.Decode(bytes.NewReader(data))
+		assert.Nil(t, err)
+		assert.Equal(t, pages[i].Bounds(), img.Bounds())
+	}
+
+	_, err = splitPNGs(images[0][:20])
+	assert.NotNil(t, err)
+}
+
+func TestNewImagePrinter(t *testing.T) {
+	dir := t.TempDir()
+	p := NewImagePrinter("PNG Archive", ImagePNG, 150, dir)
+	assert.True(t, p.IsVirtual())
+	var buf bytes.Buffer
+	enc := p.virtualEncoder(150)
+	assert.Nil(t, enc.Encode(&buf, []image.Image{newTestPage(2, 2, color.Black),
+		newTestPage(2, 2, color.White)}))
+	assert.Nil(t, p.virtual.write("Report", &buf))
+	assert.FileExists(t, filepath.Join(dir, "Report-1.png"))
+	assert.FileExists(t, filepath.Join(dir, "Report-2.png"))
+}
+
+func TestFileTitle(t *testing.T) {
+	assert.Equal(t, "Report", fileTitle("Report"))
+	assert.Equal(t, ".._.._etc_passwd", fileTitle("../../etc/passwd"))
+	assert.Equal(t, "C__Temp_Report", fileTitle("C:\\Temp\\Report"))
+	assert.Equal(t, "untitled", fileTitle(""))
+	assert.Equal(t, "untitled", fileTitle(".."))
+}
@@ -0,0 +1,177 @@
+package print
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// NUpOrder is the order in which logical pages are placed on a sheet.
+type NUpOrder int
+
+const (
+	LeftRightTopBottom NUpOrder = iota // rows from the top, each row from the left
+	TopBottomLeftRight                 // columns from the left, each column from the top
+	RightLeftTopBottom                 // rows from the top, each row from the right
+	TopBottomRightLeft                 // columns from the right, each column from the top
+)
+
+// NUpPagesPerSheet lists the supported numbers of logical pages per sheet.
+var NUpPagesPerSheet = []int{1, 2, 4, 6, 9, 16}
+
+// NUp describes how logical pages are scaled and arranged onto physical sheets.
+type NUp struct {
+	PagesPerSheet int
+	Order         NUpOrder
+	Border        bool
+}
+
+// grid returns the number of columns and rows used to place the pages on a sheet of the
+// specified size, and whether the pages are rotated by 90 degrees to fit the cells. The
+// arrangement that gives the largest pages is chosen, so that, for example, 2-up pages
+// on a portrait sheet are rotated to landscape.
+func (n NUp) grid(sheet, page image.Point) (int, int, bool) {
+	bestCols, bestRows, bestRotated := 1, n.PagesPerSheet, false
+	var bestScale float64
+	for _, rotated := range []bool{false, true} {
+		size := page
+		if rotated {
+			size = image.Pt(page.Y, page.X)
+		}
+		for cols := 1; cols <= n.PagesPerSheet; cols++ {
+			if n.PagesPerSheet%cols != 0 {
+				continue
+			}
+			rows := n.PagesPerSheet / cols
+			scale := fitScale(size, image.Pt(sheet.X/cols, sheet.Y/rows))
+			if scale > bestScale {
+				bestCols, bestRows, bestRotated, bestScale = cols, rows, rotated, scale
+			}
+		}
+	}
+	return bestCols, bestRows, bestRotated
+}
+
+// cell returns the rectangle on the sheet that holds the i'th page of the sheet. When
+// the pages are rotated, the order applies to the sheet as it is read, turned 90
+// degrees counterclockwise so that the pages are upright.
+func (n NUp) cell(i, cols, rows int, rotated bool, sheet image.Rectangle) image.Rectangle {
+	var col, row int
+	if rotated {
+		readCol, readRow := n.position(i, rows, cols)
+		col, row = cols-1-readRow, readCol
+	} else {
+		col, row = n.position(i, cols, rows)
+	}
+	w, h := sheet.Dx()/cols, sheet.Dy()/rows
+	return image.Rect(sheet.Min.X+col*w, sheet.Min.Y+row*h,
+		sheet.Min.X+(col+1)*w, sheet.Min.Y+(row+1)*h)
+}
+
+// position returns the column and row of the i'th page in a grid of cols by rows
+// cells.
+func (n NUp) position(i, cols, rows int) (int, int) {
+	switch n.Order {
+	case TopBottomLeftRight:
+		return i / rows, i % rows
+	case RightLeftTopBottom:
+		return cols - 1 - i%cols, i / cols
+	case TopBottomRightLeft:
+		return cols - 1 - i/rows, i % rows
+	}
+	return i % cols, i / cols
+}
+
+// Impose scales and arranges the logical pages onto sheets that are the same size as
+// the first page.
+func (n NUp) Impose(pages []image.Image) []image.Image {
+	if n.PagesPerSheet <= 1 || len(pages) == 0 {
+		return pages
+	}
+	sheetRect := pages[0].Bounds()
+	cols, rows, rotated := n.grid(sheetRect.Size(), sheetRect.Size())
+	var sheets []image.Image
+	for start := 0; start < len(pages); start += n.PagesPerSheet {
+		sheet := image.NewNRGBA(sheetRect)
+		for i := 0; i < n.PagesPerSheet && start+i < len(pages); i++ {
+			page := pages[start+i]
+			if rotated {
+				page = rotate90(page)
+			}
+			r := drawFitted(sheet, n.cell(i, cols, rows, rotated, sheetRect), page)
+			if n.Border {
+				drawBorder(sheet, r, color.Black)
+			}
+		}
+		sheets = append(sheets, sheet)
+	}
+	return sheets
+}
+
+// fitScale returns the scale factor that makes an object of size src as large as
+// possible while fitting inside dst.
+func fitScale(src, dst image.Point) float64 {
+	if src.X == 0 || src.Y == 0 {
+		return 0
+	}
+	sx := float64(dst.X) / float64(src.X)
+	sy := float64(dst.Y) / float64(src.Y)
+	if sx < sy {
+		return sx
+	}
+	return sy
+}
+
+// drawFitted scales src to fit inside r, centers it, and draws it onto dst.
+//
+// Returns the rectangle that src was drawn in.
+func drawFitted(dst draw.Image, r image.Rectangle, src image.Image) image.Rectangle {
+	scale := fitScale(src.Bounds().Size(), r.Size())
+	w := int(float64(src.Bounds().Dx()) * scale)
+	h := int(float64(src.Bounds().Dy()) * scale)
+	min := r.Min.Add(image.Pt((r.Dx()-w)/2, (r.Dy()-h)/2))
+	target := image.Rectangle{Min: min, Max: min.Add(image.Pt(w, h))}
+	xdraw.ApproxBiLinear.Scale(dst, target, src, src.Bounds(), xdraw.Over, nil)
+	return target
+}
+
+// drawBorder draws a one pixel wide border just inside r.
+func drawBorder(dst draw.Image, r image.Rectangle, c color.Color) {
+	if r.Empty() {
+		return
+	}
+	for x := r.Min.X; x < r.Max.X; x++ {
+		dst.Set(x, r.Min.Y, c)
+		dst.Set(x, r.Max.Y-1, c)
+	}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		dst.Set(r.Min.X, y, c)
+		dst.Set(r.Max.X-1, y, c)
+	}
+}
+
+// rotate90 returns a copy of img rotated 90 degrees clockwise.
+func rotate90(img image.Image) *image.NRGBA {
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dy(), b.Dx()))
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			dst.Set(b.Max.Y-1-y, x-b.Min.X, img.At(x, y))
+		}
+	}
+	return dst
+}
+
+// rotate180 returns a copy of img rotated by 180 degrees.
+func rotate180(img image.Image) *image.NRGBA {
+	b := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			dst.Set(b.Max.X-1-x, b.Max.Y-1-y, img.At(x, y))
+		}
+	}
+	return dst
+}
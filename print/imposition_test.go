@@ -0,0 +1,99 @@
+package print
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPage(w, h int, c color.Color) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestNUp_Grid(t *testing.T) {
+	sheet := image.Pt(612, 792)
+	tests := []struct {
+		n, cols, rows int
+		rotated       bool
+	}{
+		{2, 1, 2, true},
+		{4, 2, 2, false},
+		{6, 2, 3, true},
+		{9, 3, 3, false},
+		{16, 4, 4, false},
+	}
+	for _, tt := range tests {
+		cols, rows, rotated := NUp{PagesPerSheet: tt.n}.grid(sheet, sheet)
+		assert.Equal(t, tt.cols, cols, "columns for %d-up", tt.n)
+		assert.Equal(t, tt.rows, rows, "rows for %d-up", tt.n)
+		assert.Equal(t, tt.rotated, rotated, "rotation for %d-up", tt.n)
+	}
+}
+
+func TestNUp_Impose2UpScale(t *testing.T) {
+	// Rotated to landscape, a letter page is scaled by 396/612 to fill the height of a
+	// half sheet, instead of by 1/2 when it is not rotated.
+	pages := []image.Image{newTestPage(612, 792, color.Black)}
+	sheets := NUp{PagesPerSheet: 2}.Impose(pages)
+	sheet := sheets[0].(*image.NRGBA)
+	drawn := image.Rectangle{}
+	for y := 0; y < 792; y++ {
+		for x := 0; x < 612; x++ {
+			if sheet.NRGBAAt(x, y).A != 0 {
+				drawn = drawn.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	assert.Equal(t, image.Rect(50, 0, 562, 396), drawn)
+}
+
+func TestNUp_Cell(t *testing.T) {
+	sheet := image.Rect(0, 0, 200, 200)
+	n := NUp{PagesPerSheet: 4, Order: LeftRightTopBottom}
+	assert.Equal(t, image.Rect(100, 0, 200, 100), n.cell(1, 2, 2, false, sheet))
+	n.Order = TopBottomLeftRight
+	assert.Equal(t, image.Rect(0, 100, 100, 200), n.cell(1, 2, 2, false, sheet))
+	n.Order = RightLeftTopBottom
+	assert.Equal(t, image.Rect(100, 0, 200, 100), n.cell(0, 2, 2, false, sheet))
+	n.Order = TopBottomRightLeft
+	assert.Equal(t, image.Rect(100, 100, 200, 200), n.cell(1, 2, 2, false, sheet))
+
+	// rotated 2-up: the first page is on top, which is on the left when the sheet is read
+	n = NUp{PagesPerSheet: 2, Order: LeftRightTopBottom}
+	assert.Equal(t, image.Rect(0, 0, 200, 100), n.cell(0, 1, 2, true, sheet))
+	assert.Equal(t, image.Rect(0, 100, 200, 200), n.cell(1, 1, 2, true, sheet))
+}
+
+func TestNUp_Impose(t *testing.T) {
+	pages := []image.Image{
+		newTestPage(100, 100, color.Black),
+		newTestPage(100, 100, color.White),
+		newTestPage(100, 100, color.Black),
+		newTestPage(100, 100, color.Black),
+		newTestPage(100, 100, color.Black),
+	}
+	sheets := NUp{PagesPerSheet: 4}.Impose(pages)
+	assert.Equal(t, 2, len(sheets))
+	assert.Equal(t, image.Rect(0, 0, 100, 100), sheets[0].Bounds())
+	r, _, _, _ := sheets[0].At(25, 25).RGBA()
+	assert.Equal(t, uint32(0), r)
+	r, _, _, _ = sheets[0].At(75, 25).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+	// only the first cell of the last sheet is used
+	_, _, _, a := sheets[1].At(75, 75).RGBA()
+	assert.Equal(t, uint32(0), a)
+
+	sheets = NUp{PagesPerSheet: 2, Border: true}.Impose(pages[1:2])
+	r, _, _, _ = sheets[0].At(25, 0).RGBA()
+	assert.Equal(t, uint32(0), r)
+
+	assert.Equal(t, pages, NUp{PagesPerSheet: 1}.Impose(pages))
+}
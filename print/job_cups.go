@@ -0,0 +1,87 @@
+//go:build !windows
+
+package print
+
+// #include <stdlib.h>
+// #include "cups/cups.h"
+import "C"
+import (
+	"errors"
+	"io"
+	"unsafe"
+)
+
+// SubmitJob creates a print job on the printer and sends the document to it.
+//
+// Params:
+//
+//	title is the job title that is displayed in the print queue.
+//	format is the MIME type of the document, for example URFFormat. Virtual printers
+//	save the document in their own format, which is returned by their encoder. Use
+//	RawFormat to send a document that is already in the printer's language to a raw
+//	queue.
+//	options contains IPP job template attributes and values, such as "copies".
+//	document is the data to print.
+//
+// Returns the ID of the created job. Virtual printers do not create jobs and return 0.
+// The PDF printer returns as soon as its save dialog is shown, before the file is
+// chosen; the document is written when the user chooses the file, and errors writing
+// it are shown in a dialog.
+func (p *Printer) SubmitJob(title, format string, options map[string]string,
+	document io.Reader) (int, error) {
+	if p.virtual != nil {
+		return 0, p.virtual.write(title, document)
+	}
+	numOptions, cOptions := newCupsOptions(options)
+	defer C.cupsFreeOptions(numOptions, cOptions)
+
+	cTitle := C.CString(title)
+	defer C.free(unsafe.Pointer(cTitle))
+	cFormat := C.CString(format)
+	defer C.free(unsafe.Pointer(cFormat))
+
+	var jobID C.int
+	if C.cupsCreateDestJob(p.http, p.dest, p.dinfo, &jobID, cTitle,
+		numOptions, cOptions) != C.IPP_STATUS_OK {
+		return 0, lastCupsError()
+	}
+	if C.cupsStartDestDocument(p.http, p.dest, p.dinfo, jobID, cTitle, cFormat,
+		0, nil, 1) != C.HTTP_STATUS_CONTINUE {
+		return 0, p.cancelJob(jobID, lastCupsError())
+	}
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := document.Read(buf)
+		if n > 0 && C.cupsWriteRequestData(p.http, (*C.char)(unsafe.Pointer(&buf[0])),
+			C.size_t(n)) != C.HTTP_STATUS_CONTINUE {
+			err := lastCupsError()
+			C.cupsFinishDestDocument(p.http, p.dest, p.dinfo)
+			return 0, p.cancelJob(jobID, err)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			C.cupsFinishDestDocument(p.http, p.dest, p.dinfo)
+			return 0, p.cancelJob(jobID, err)
+		}
+	}
+	if C.cupsFinishDestDocument(p.http, p.dest, p.dinfo) != C.IPP_STATUS_OK {
+		return 0, p.cancelJob(jobID, lastCupsError())
+	}
+	return int(jobID), nil
+}
+
+// cancelJob cancels a job whose document could not be sent, so that no held or partial
+// job is left in the print queue.
+//
+// Returns err, the error that caused the job to be canceled.
+func (p *Printer) cancelJob(jobID C.int, err error) error {
+	C.cupsCancelDestJob(p.http, p.dest, jobID)
+	return err
+}
+
+// lastCupsError returns the last CUPS error message as an error.
+func lastCupsError() error {
+	return errors.New(C.GoString(C.cupsLastErrorString()))
+}
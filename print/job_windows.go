@@ -0,0 +1,77 @@
+//go:build windows
+
+package print
+
+import (
+	"errors"
+	"io"
+	"syscall"
+)
+
+// SubmitJob creates a print job on the printer and sends the document to it.
+//
+// Params:
+//
+//	title is the job title that is displayed in the print queue.
+//	format is the MIME type of the document. Windows printer drivers only accept
+//	documents that are already in the printer's language, so this must be RawFormat.
+//	Virtual printers save the document in their own format, which is returned by their
+//	encoder.
+//	options is ignored on Windows. Settings such as copies must be encoded in the
+//	document itself.
+//	document is the data to print.
+//
+// Returns the ID of the created job. Virtual printers do not create jobs and return 0.
+// The PDF printer returns as soon as its save dialog is shown, before the file is
+// chosen; the document is written when the user chooses the file, and errors writing
+// it are shown in a dialog.
+func (p *Printer) SubmitJob(title, format string, options map[string]string,
+	document io.Reader) (int, error) {
+	if p.virtual != nil {
+		return 0, p.virtual.write(title, document)
+	}
+	if format != RawFormat {
+		return 0, errors.New("only raw documents can be submitted to Windows printers")
+	}
+	docName, _ := syscall.UTF16FromString(title)
+	datatype, _ := syscall.UTF16FromString("RAW")
+	jobID, err := startDocPrinter(p.handle, &docInfo1{docName: &docName[0],
+		datatype: &datatype[0]})
+	if err != nil {
+		return 0, err
+	}
+	if err = startPagePrinter(p.handle); err != nil {
+		return 0, p.abortJob(err)
+	}
+	buf := make([]byte, 64*1024)
+	for {
+		n, rErr := document.Read(buf)
+		if n > 0 {
+			if _, err = writePrinter(p.handle, buf[:n]); err != nil {
+				return 0, p.abortJob(err)
+			}
+		}
+		if rErr == io.EOF {
+			break
+		}
+		if rErr != nil {
+			return 0, p.abortJob(rErr)
+		}
+	}
+	if err = endPagePrinter(p.handle); err != nil {
+		return 0, p.abortJob(err)
+	}
+	if err = endDocPrinter(p.handle); err != nil {
+		return 0, p.abortJob(err)
+	}
+	return int(jobID), nil
+}
+
+// abortJob deletes a job whose document could not be sent, so that no partial job is
+// left in the spooler.
+//
+// Returns err, the error that caused the job to be aborted.
+func (p *Printer) abortJob(err error) error {
+	abortPrinter(p.handle)
+	return err
+}
@@ -0,0 +1,98 @@
+package print
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// JobOptions are IPP job template attributes and their values, such as "copies": "2".
+type JobOptions map[string]string
+
+// Conflict describes a job option that the printer does not accept.
+type Conflict struct {
+	// Option is the name of the job option, for example "sides".
+	Option string
+	// Value is the value that was requested.
+	Value string
+	// Message describes the conflict so that it can be shown next to the option.
+	Message string
+}
+
+// Conflicts are the job options that a printer does not accept. Conflicts implements
+// error so that it can be returned when a job is rejected.
+type Conflicts []Conflict
+
+// Error returns the messages of all of the conflicts.
+func (c Conflicts) Error() string {
+	messages := make([]string, len(c))
+	for i, conflict := range c {
+		messages[i] = conflict.Message
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Option returns the conflict for a job option, if there is one.
+func (c Conflicts) Option(option string) (Conflict, bool) {
+	for _, conflict := range c {
+		if conflict.Option == option {
+			return conflict, true
+		}
+	}
+	return Conflict{}, false
+}
+
+// newConflict creates the conflict for an option value that is not supported.
+func newConflict(option, value string) Conflict {
+	return Conflict{Option: option, Value: value,
+		Message: fmt.Sprintf("%s: %s is not supported by the printer", option, value)}
+}
+
+// names returns the option names in alphabetical order.
+func (o JobOptions) names() []string {
+	names := make([]string, 0, len(o))
+	for name := range o {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OptionConflicts describes job options that cannot be used together, and the options
+// that resolve the conflict.
+type OptionConflicts struct {
+	// Conflicting are the options that conflict with each other. It is empty if there
+	// is no conflict.
+	Conflicting JobOptions
+	// Resolved are the options that must be changed to resolve the conflict. It is
+	// empty if the conflict cannot be resolved.
+	Resolved JobOptions
+}
+
+// HasConflict returns true if there are conflicting options.
+func (c OptionConflicts) HasConflict() bool {
+	return len(c.Conflicting) > 0
+}
+
+// Resolvable returns true if the conflict can be resolved by changing the Resolved
+// options.
+func (c OptionConflicts) Resolvable() bool {
+	return len(c.Resolved) > 0
+}
+
+// Apply returns a copy of the options with the resolved options applied. Resolved
+// options with an empty value are removed.
+func (c OptionConflicts) Apply(options JobOptions) JobOptions {
+	applied := JobOptions{}
+	for name, value := range options {
+		applied[name] = value
+	}
+	for name, value := range c.Resolved {
+		if value == "" {
+			delete(applied, name)
+		} else {
+			applied[name] = value
+		}
+	}
+	return applied
+}
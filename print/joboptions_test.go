@@ -0,0 +1,54 @@
+package print
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConflicts(t *testing.T) {
+	conflicts := Conflicts{newConflict("sides", "two-sided-long-edge"),
+		newConflict("media", "iso_a3_297x420mm")}
+	assert.Equal(t, "sides: two-sided-long-edge is not supported by the printer\n"+
+		"media: iso_a3_297x420mm is not supported by the printer", conflicts.Error())
+	conflict, ok := conflicts.Option("media")
+	assert.True(t, ok)
+	assert.Equal(t, "iso_a3_297x420mm", conflict.Value)
+	_, ok = conflicts.Option("copies")
+	assert.False(t, ok)
+}
+
+func TestJobOptions_Names(t *testing.T) {
+	options := JobOptions{"sides": "one-sided", "copies": "2", "media": "na_letter_8.5x11in"}
+	assert.Equal(t, []string{"copies", "media", "sides"}, options.names())
+}
+
+func TestOptionConflicts_Apply(t *testing.T) {
+	c := OptionConflicts{Conflicting: JobOptions{"sides": "two-sided-long-edge",
+		"media-type": "transparency"},
+		Resolved: JobOptions{"media-type": "stationery", "finishings": ""}}
+	assert.True(t, c.HasConflict())
+	assert.True(t, c.Resolvable())
+	options := JobOptions{"sides": "two-sided-long-edge", "media-type": "transparency",
+		"finishings": "4"}
+	assert.Equal(t, JobOptions{"sides": "two-sided-long-edge", "media-type": "stationery"},
+		c.Apply(options))
+	assert.Equal(t, "transparency", options["media-type"])
+	assert.False(t, OptionConflicts{}.HasConflict())
+}
+
+func TestPrintOperation_ApplyConflicts(t *testing.T) {
+	po := &PrintOperation{}
+	po.SetOptions(JobOptions{"media-type": "transparency", "finishings": "4"})
+	c := OptionConflicts{Conflicting: JobOptions{"sides": "two-sided-long-edge",
+		"media-type": "transparency"},
+		Resolved: JobOptions{"media-type": "stationery", "finishings": ""}}
+	po.ApplyConflicts("sides", "two-sided-long-edge", c)
+	assert.Equal(t, JobOptions{"sides": "two-sided-long-edge", "media-type": "stationery"},
+		po.Options())
+	// the options are submitted with the job, and the booklet sides replace them
+	po.pageSetupInfo = &PageSetupInfo{booklet: &Booklet{}}
+	options := po.jobOptions()
+	assert.Equal(t, "stationery", options["media-type"])
+	assert.Equal(t, (&Booklet{}).Sides(), options["sides"])
+}
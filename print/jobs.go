@@ -0,0 +1,56 @@
+package print
+
+import (
+	"image"
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+// RawFormat is the MIME type used to send a document that is already in the printer's
+// language, such as PCL, to a raw queue.
+const RawFormat = "application/vnd.cups-raw"
+
+// PageEncoder converts rendered pages into a document in a printer's language.
+type PageEncoder interface {
+	// Format returns the MIME type of the encoded document.
+	Format() string
+	// Encode writes the pages to w.
+	Encode(w io.Writer, pages []image.Image) error
+}
+
+// SendToDevice writes a document directly to a file or device path, such as a serial
+// port or a USB printer device, bypassing the print system. The document must already
+// be in the printer's language.
+func SendToDevice(path string, document io.Reader) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, document); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// SendToHost sends a document directly to a network printer, bypassing the print
+// system. This is usually used with the raw printing port 9100 of label and laser
+// printers. The document must already be in the printer's language.
+//
+// Params:
+//
+//	address is the host and port of the printer, for example "192.168.1.20:9100".
+//	document is the data to print.
+func SendToHost(address string, document io.Reader) error {
+	conn, err := net.DialTimeout("tcp", address, 10*time.Second)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(conn, document); err != nil {
+		conn.Close()
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,41 @@
+package print
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendToDevice(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "receipt.bin")
+	assert.Nil(t, SendToDevice(path, strings.NewReader("receipt")))
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "receipt", string(data))
+
+	assert.NotNil(t, SendToDevice(filepath.Join(path, "bad"), strings.NewReader("")))
+}
+
+func TestSendToHost(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer l.Close()
+	received := make(chan string)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			received <- ""
+			return
+		}
+		data, _ := io.ReadAll(conn)
+		conn.Close()
+		received <- string(data)
+	}()
+	assert.Nil(t, SendToHost(l.Addr().String(), strings.NewReader("^XA^XZ")))
+	assert.Equal(t, "^XA^XZ", <-received)
+}
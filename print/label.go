@@ -0,0 +1,221 @@
+package print
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// LabelLanguage is the printer language used for label printers.
+type LabelLanguage int
+
+const (
+	ZPL LabelLanguage = iota // Zebra Programming Language (ZPL II)
+	EPL                      // Eltron Programming Language (EPL2)
+)
+
+// LabelSize is a custom label media size in millimeters.
+type LabelSize struct {
+	Width  float32
+	Length float32
+}
+
+// Common label sizes.
+var (
+	Label4x6in = LabelSize{Width: 101.6, Length: 152.4}
+	Label4x2in = LabelSize{Width: 101.6, Length: 50.8}
+	Label2x1in = LabelSize{Width: 50.8, Length: 25.4}
+)
+
+// Dots returns the label width and length in dots at the specified resolution.
+func (s LabelSize) Dots(dpi int) (int, int) {
+	return int(s.Width*float32(dpi)/25.4 + 0.5), int(s.Length*float32(dpi)/25.4 + 0.5)
+}
+
+// PWGName returns the PWG self-describing media name for the label size, which can be
+// passed as the "media" job option.
+func (s LabelSize) PWGName() string {
+	return fmt.Sprintf("custom_label_%gx%gmm", s.Width, s.Length)
+}
+
+// LabelElement is a native text or barcode command that is printed on top of the
+// rendered label graphic.
+type LabelElement interface {
+	zpl() string
+	epl() string
+}
+
+// LabelText is native printer text. X, Y, and Height are in dots.
+type LabelText struct {
+	X, Y   int
+	Height int
+	Text   string
+}
+
+// zpl returns the ZPL commands that print the text.
+func (t LabelText) zpl() string {
+	return fmt.Sprintf("^FO%d,%d^A0N,%d,%d^FH^FD%s^FS", t.X, t.Y, t.Height, t.Height,
+		zplFieldData(t.Text))
+}
+
+// eplFont4Height is the height in dots of a character cell of EPL font 4.
+const eplFont4Height = 24
+
+// epl returns the EPL command that prints the text.
+func (t LabelText) epl() string {
+	// Font 4 is 24 dots high; larger heights are produced with the vertical multiplier,
+	// which EPL limits to 9. The horizontal multiplier keeps the aspect ratio, up to 6.
+	vMult := (t.Height + eplFont4Height/2) / eplFont4Height
+	if vMult < 1 {
+		vMult = 1
+	} else if vMult > 9 {
+		vMult = 9
+	}
+	hMult := vMult
+	if hMult > 6 {
+		hMult = 6
+	}
+	return fmt.Sprintf("A%d,%d,0,4,%d,%d,N,%s\n", t.X, t.Y, hMult, vMult, eplQuote(t.Text))
+}
+
+// LabelBarcode is a native Code 128 barcode with human readable text below it. X, Y,
+// and Height are in dots.
+type LabelBarcode struct {
+	X, Y   int
+	Height int
+	Data   string
+}
+
+// zpl returns the ZPL commands that print the barcode.
+func (b LabelBarcode) zpl() string {
+	return fmt.Sprintf("^FO%d,%d^BCN,%d,Y,N,N^FH^FD%s^FS", b.X, b.Y, b.Height,
+		zplFieldData(b.Data))
+}
+
+// epl returns the EPL command that prints the barcode.
+func (b LabelBarcode) epl() string {
+	return fmt.Sprintf("B%d,%d,0,1,2,4,%d,B,%s\n", b.X, b.Y, b.Height, eplQuote(b.Data))
+}
+
+// zplFieldData escapes the ZPL control characters in field data. It must be used
+// with the ^FH command so that the escapes are interpreted as hexadecimal.
+func zplFieldData(s string) string {
+	r := strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E")
+	return r.Replace(s)
+}
+
+// eplQuote quotes an EPL string parameter.
+func eplQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// Declare conformity with PageEncoder interface
+var _ PageEncoder = (*LabelEncoder)(nil)
+
+// LabelEncoder writes rendered label pages as ZPL graphic fields (^GF) or EPL graphics
+// (GW), optionally followed by native text and barcode elements.
+type LabelEncoder struct {
+	Language   LabelLanguage
+	Resolution int
+	Size       LabelSize
+	Copies     int
+	Elements   []LabelElement
+}
+
+// NewLabelEncoder creates a LabelEncoder that prints one copy of each label.
+//
+// Params:
+//
+//	language is the printer's language.
+//	size is the label media size.
+//	resolution is the printer's resolution in dots per inch, usually 203 or 300.
+func NewLabelEncoder(language LabelLanguage, size LabelSize, resolution int) *LabelEncoder {
+	return &LabelEncoder{Language: language, Resolution: resolution, Size: size, Copies: 1}
+}
+
+// Format returns the MIME type of the documents created by the encoder. Label documents
+// must be sent to a raw queue or directly to the printer.
+func (e *LabelEncoder) Format() string {
+	return RawFormat
+}
+
+// Encode writes each page as a separate label to w.
+func (e *LabelEncoder) Encode(w io.Writer, pages []image.Image) error {
+	if len(pages) == 0 {
+		return errors.New("no pages to encode")
+	}
+	copies := e.Copies
+	if copies < 1 {
+		copies = 1
+	}
+	var buf bytes.Buffer
+	for _, page := range pages {
+		if e.Language == EPL {
+			e.encodeEPL(&buf, page, copies)
+		} else {
+			e.encodeZPL(&buf, page, copies)
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// encodeZPL writes a single ZPL label.
+func (e *LabelEncoder) encodeZPL(buf *bytes.Buffer, page image.Image, copies int) {
+	width, length := e.Size.Dots(e.Resolution)
+	rows := monoRows(page)
+	fmt.Fprintf(buf, "^XA^PW%d^LL%d^LH0,0", width, length)
+	if len(rows) > 0 {
+		bytesPerRow := len(rows[0])
+		total := bytesPerRow * len(rows)
+		fmt.Fprintf(buf, "^FO0,0^GFA,%d,%d,%d,", total, total, bytesPerRow)
+		for _, row := range rows {
+			buf.WriteString(strings.ToUpper(hex.EncodeToString(row)))
+		}
+		buf.WriteString("^FS")
+	}
+	for _, el := range e.Elements {
+		buf.WriteString(el.zpl())
+	}
+	fmt.Fprintf(buf, "^PQ%d^XZ\n", copies)
+}
+
+// encodeEPL writes a single EPL label. EPL graphics use a cleared bit for each
+// black dot.
+func (e *LabelEncoder) encodeEPL(buf *bytes.Buffer, page image.Image, copies int) {
+	width, length := e.Size.Dots(e.Resolution)
+	rows := monoRows(page)
+	fmt.Fprintf(buf, "\nN\nq%d\nQ%d,24\n", width, length)
+	if len(rows) > 0 {
+		fmt.Fprintf(buf, "GW0,0,%d,%d,", len(rows[0]), len(rows))
+		for _, row := range rows {
+			for _, b := range row {
+				buf.WriteByte(^b)
+			}
+		}
+		buf.WriteString("\n")
+	}
+	for _, el := range e.Elements {
+		buf.WriteString(el.epl())
+	}
+	fmt.Fprintf(buf, "P%d\n", copies)
+}
+
+// RenderLabel renders a canvas object so that it fills a label.
+//
+// Params:
+//
+//	obj is the label content.
+//	size is the label media size.
+//	resolution is the printer's resolution in dots per inch.
+func RenderLabel(obj fyne.CanvasObject, size LabelSize, resolution int) image.Image {
+	s := fyne.NewSize(mmToPoints(size.Width), mmToPoints(size.Length))
+	return renderObject(obj, s, resolution)
+}
@@ -0,0 +1,53 @@
+package print
+
+import (
+	"bytes"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelSize(t *testing.T) {
+	w, l := Label4x6in.Dots(203)
+	assert.Equal(t, 812, w)
+	assert.Equal(t, 1218, l)
+	assert.Equal(t, "custom_label_50.8x25.4mm", Label2x1in.PWGName())
+}
+
+func TestLabelEncoder_EncodeZPL(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 16, 2))
+	e := NewLabelEncoder(ZPL, Label2x1in, 203)
+	e.Copies = 3
+	e.Elements = []LabelElement{
+		LabelText{X: 10, Y: 20, Height: 30, Text: "A^B"},
+		LabelBarcode{X: 10, Y: 60, Height: 50, Data: "12345"},
+	}
+	var buf bytes.Buffer
+	assert.Nil(t, e.Encode(&buf, []image.Image{img}))
+	assert.Equal(t, "^XA^PW406^LL203^LH0,0^FO0,0^GFA,4,4,2,FFFFFFFF^FS"+
+		"^FO10,20^A0N,30,30^FH^FDA_5EB^FS^FO10,60^BCN,50,Y,N,N^FH^FD12345^FS^PQ3^XZ\n",
+		buf.String())
+}
+
+func TestLabelEncoder_EncodeEPL(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 8, 1))
+	e := NewLabelEncoder(EPL, Label2x1in, 203)
+	e.Elements = []LabelElement{LabelText{X: 5, Y: 5, Height: 64, Text: `say "hi"`}}
+	var buf bytes.Buffer
+	assert.Nil(t, e.Encode(&buf, []image.Image{img}))
+	assert.Equal(t, "\nN\nq406\nQ203,24\nGW0,0,1,1,\x00\n"+
+		"A5,5,0,4,3,3,N,\"say \\\"hi\\\"\"\nP1\n", buf.String())
+}
+
+func TestLabelText_EPLHeight(t *testing.T) {
+	assert.Equal(t, "A0,0,0,4,1,1,N,\"x\"\n", LabelText{Height: 10, Text: "x"}.epl())
+	assert.Equal(t, "A0,0,0,4,2,2,N,\"x\"\n", LabelText{Height: 50, Text: "x"}.epl())
+	assert.Equal(t, "A0,0,0,4,4,4,N,\"x\"\n", LabelText{Height: 100, Text: "x"}.epl())
+	assert.Equal(t, "A0,0,0,4,6,9,N,\"x\"\n", LabelText{Height: 400, Text: "x"}.epl())
+}
+
+func TestLabelEncoder_EncodeNoPages(t *testing.T) {
+	e := NewLabelEncoder(ZPL, Label4x6in, 203)
+	assert.NotNil(t, e.Encode(&bytes.Buffer{}, nil))
+}
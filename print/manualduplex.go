@@ -0,0 +1,51 @@
+package print
+
+import (
+	"image"
+)
+
+// ManualDuplex describes two-sided printing on printers that cannot duplex. The fronts
+// of the sheets are printed first, the user turns the printed stack over and puts it
+// back into the printer, and then the backs are printed in reverse order.
+type ManualDuplex struct {
+	// ShortEdge binds the sheets on their short edge. The backs are rotated by 180
+	// degrees so that the stack is always turned over on its long edge. By default,
+	// the sheets are bound on their long edge.
+	ShortEdge bool
+}
+
+// Passes splits the sides of the sheets into the two printing passes.
+//
+// Params:
+//
+//	sides are the pages to print, alternating between the front and back of each sheet.
+//
+// Returns the fronts in printing order, and the backs in reverse order. A blank back is
+// added to the last sheet if there is an odd number of sides.
+func (m ManualDuplex) Passes(sides []image.Image) ([]image.Image, []image.Image) {
+	sides = padSides(sides)
+	fronts := make([]image.Image, 0, len(sides)/2)
+	backs := make([]image.Image, 0, len(sides)/2)
+	for i := 0; i < len(sides); i += 2 {
+		fronts = append(fronts, sides[i])
+	}
+	for i := len(sides) - 1; i > 0; i -= 2 {
+		back := sides[i]
+		if m.ShortEdge {
+			back = rotate180(back)
+		}
+		backs = append(backs, back)
+	}
+	return fronts, backs
+}
+
+// Instructions returns the text that tells the user how to reinsert the printed stack
+// before the backs are printed. The backs are laid out so that the top edge of the
+// sheets enters the printer first for both passes.
+func (m ManualDuplex) Instructions() string {
+	return "The fronts of the sheets have been printed.\n\n" +
+		"Without changing the order of the sheets, take the printed stack from the " +
+		"output tray and put it back into the input tray so that the blank sides are " +
+		"printed on. Keep the top edge of the sheets entering the printer first, as it " +
+		"did for the fronts.\n\nPress OK to print the backs."
+}
@@ -0,0 +1,46 @@
+package print
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManualDuplex_Passes(t *testing.T) {
+	var sides []image.Image
+	for i := 1; i <= 5; i++ {
+		sides = append(sides, newTestPage(i, i, color.Black))
+	}
+	fronts, backs := ManualDuplex{}.Passes(sides)
+	assert.Equal(t, []image.Image{sides[0], sides[2], sides[4]}, fronts)
+	assert.Len(t, backs, 3)
+	assert.Equal(t, sides[4].Bounds(), backs[0].Bounds())
+	assert.Equal(t, uint32(0), alphaAt(backs[0], 0, 0))
+	assert.Equal(t, []image.Image{sides[3], sides[1]}, backs[1:])
+}
+
+func TestManualDuplex_ShortEdge(t *testing.T) {
+	back := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	back.Set(0, 0, color.Black)
+	_, backs := ManualDuplex{ShortEdge: true}.Passes([]image.Image{newTestPage(2, 2,
+		color.White), back})
+	assert.Len(t, backs, 1)
+	assert.Equal(t, uint32(0), alphaAt(backs[0], 0, 0))
+	assert.Equal(t, uint32(0xffff), alphaAt(backs[0], 1, 1))
+}
+
+func TestPrintOperation_PrintManualDuplexWithoutWindow(t *testing.T) {
+	// without an app there is no window to show the instructions in
+	fyne.SetCurrentApp(nil)
+	defer test.NewApp()
+	po := &PrintOperation{manualDuplex: &ManualDuplex{}}
+	po.SetDocument(NewDocument("Report"))
+	// the error is returned before the fronts are encoded and submitted
+	err := po.printManualDuplex(&Printer{}, nil, JobOptions{},
+		[]image.Image{newTestPage(2, 2, color.Black), newTestPage(2, 2, color.Black)})
+	assert.NotNil(t, err)
+}
@@ -23,3 +23,23 @@ func (m Margins) String() string {
 	s.WriteString(fmt.Sprintf("    right: %.2f\n", m.right))
 	return s.String()
 }
+
+// Top returns the top margin.
+func (m Margins) Top() float32 {
+	return m.top
+}
+
+// Bottom returns the bottom margin.
+func (m Margins) Bottom() float32 {
+	return m.bottom
+}
+
+// Left returns the left margin.
+func (m Margins) Left() float32 {
+	return m.left
+}
+
+// Right returns the right margin.
+func (m Margins) Right() float32 {
+	return m.right
+}
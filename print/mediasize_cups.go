@@ -7,6 +7,8 @@ import "C"
 import (
 	"fmt"
 	"strings"
+
+	"fyne.io/fyne/v2"
 )
 
 // MediaSize contains the PWG name, localized name, width, length, and margins for
@@ -25,6 +27,16 @@ func newMediaSize(cupsSize *C.cups_size_t, pr *Printer) MediaSize {
 	return s
 }
 
+// newStandardMediaSize creates a MediaSize object with no margins from a media size in
+// the standard media catalog.
+func newStandardMediaSize(m standardMediaSize) MediaSize {
+	size := &C.cups_size_t{width: C.int(m.width * 100), length: C.int(m.length * 100)}
+	for i := 0; i < len(m.pwgName) && i < len(size.media)-1; i++ {
+		size.media[i] = C.char(m.pwgName[i])
+	}
+	return MediaSize{size: size, localName: m.localName}
+}
+
 // String converts the MediaSize object to a string (for printing).
 func (s *MediaSize) String() string {
 	var b strings.Builder
@@ -33,7 +45,7 @@ func (s *MediaSize) String() string {
 	b.WriteString(fmt.Sprintf("    Local Name: %s\n", s.LocalName()))
 	b.WriteString(fmt.Sprintf("    Width: %d\n", s.Width()))
 	b.WriteString(fmt.Sprintf("    Length: %d\n", s.Length()))
-	b.WriteString(s.Margins().AsString())
+	b.WriteString(s.Margins().String())
 	return b.String()
 }
 
@@ -60,6 +72,25 @@ func (s *MediaSize) Length() int {
 
 // Margins retrieves the margins for the media size.
 func (s *MediaSize) Margins() *Margins {
-	return &Margins{left: int(s.size.left), right: int(s.size.right),
-		top: int(s.size.top), bottom: int(s.size.bottom)}
+	return &Margins{left: float32(s.size.left), right: float32(s.size.right),
+		top: float32(s.size.top), bottom: float32(s.size.bottom)}
+}
+
+// pclPageSize returns the PCL page size code for the media size, or 0 if PCL does
+// not define the size.
+func (s *MediaSize) pclPageSize() int {
+	return pclPageSizeFromPWG(s.MediaName())
+}
+
+// pageSize returns the size of the media in points. CUPS media sizes are in
+// hundredths of a millimeter.
+func (s *MediaSize) pageSize() fyne.Size {
+	return fyne.NewSize(mmToPoints(float32(s.Width())/100), mmToPoints(float32(s.Length())/100))
+}
+
+// pageMargins returns the margins of the media in points.
+func (s *MediaSize) pageMargins() Margins {
+	m := s.Margins()
+	return Margins{left: mmToPoints(m.left / 100), right: mmToPoints(m.right / 100),
+		top: mmToPoints(m.top / 100), bottom: mmToPoints(m.bottom / 100)}
 }
@@ -5,6 +5,8 @@ package print
 import (
 	"fmt"
 	"strings"
+
+	"fyne.io/fyne/v2"
 )
 
 // MediaSize contains media size info for Windows paper sizes.
@@ -46,6 +48,13 @@ func newMediaSizeWithData(name string, size paperSize, width, height, imageableW
 	}
 }
 
+// newStandardMediaSize creates a MediaSize object with no margins from a media size in
+// the standard media catalog.
+func newStandardMediaSize(m standardMediaSize) MediaSize {
+	return newMediaSizeWithData(m.localName, paperSize(m.dmPaper), m.width, m.length,
+		m.width, m.length, 0, 0)
+}
+
 // setData sets size and margins data for a Windows MediaSize object
 func (ms *MediaSize) setData(width, height, imageableWidth, imageableHeight, offsetX, offsetY float32) {
 	ms.width = width
@@ -104,3 +113,16 @@ func (ms *MediaSize) String() string {
 	}
 	return s.String()
 }
+
+// pageSize returns the size of the media in points. Windows media sizes are in
+// millimeters.
+func (ms *MediaSize) pageSize() fyne.Size {
+	return fyne.NewSize(mmToPoints(ms.width), mmToPoints(ms.height))
+}
+
+// pageMargins returns the margins of the media in points.
+func (ms *MediaSize) pageMargins() Margins {
+	m := ms.Margins()
+	return Margins{left: mmToPoints(m.left), right: mmToPoints(m.right),
+		top: mmToPoints(m.top), bottom: mmToPoints(m.bottom)}
+}
@@ -0,0 +1,152 @@
+//go:build !windows
+
+package print
+
+// #include <stdlib.h>
+// #include "cups/cups.h"
+import "C"
+import (
+	"fmt"
+	"strconv"
+	"unsafe"
+)
+
+// newCupsOptions converts job options to a CUPS options array. The array must be freed
+// with cupsFreeOptions.
+func newCupsOptions(options JobOptions) (C.int, *C.cups_option_t) {
+	var numOptions C.int
+	var cOptions *C.cups_option_t
+	for name, value := range options {
+		cName := C.CString(name)
+		cValue := C.CString(value)
+		numOptions = C.cupsAddOption(cName, cValue, numOptions, &cOptions)
+		C.free(unsafe.Pointer(cName))
+		C.free(unsafe.Pointer(cValue))
+	}
+	return numOptions, cOptions
+}
+
+// newJobOptions converts a CUPS options array to job options.
+func newJobOptions(numOptions C.int, cOptions *C.cups_option_t) JobOptions {
+	options := JobOptions{}
+	oPtr := uintptr(unsafe.Pointer(cOptions))
+	for i := 0; i < int(numOptions); i++ {
+		// use of unsafe.Pointer on next line OK.
+		opt := (*C.cups_option_t)(unsafe.Pointer(oPtr))
+		options[C.GoString(opt.name)] = C.GoString(opt.value)
+		oPtr += uintptr(unsafe.Sizeof(opt.name)) + uintptr(unsafe.Sizeof(opt.value))
+	}
+	return options
+}
+
+// ResolveConflicts finds the job options that conflict with a changed option, such as
+// two-sided printing on transparencies, and the options that resolve the conflict. The
+// conflicts are found with cupsCopyDestConflicts, or with the IPP Validate-Job
+// operation if CUPS has no information about the printer. Virtual printers have no
+// conflicts.
+//
+// Params:
+//
+//	options are the current job options.
+//	option and value are the option that is changed and its new value.
+func (p *Printer) ResolveConflicts(options JobOptions, option, value string) (
+	OptionConflicts, error) {
+	if p.virtual != nil {
+		return OptionConflicts{}, nil
+	}
+	if p.dinfo == nil {
+		return resolveWithValidate(p.printerURI(), options, option, value)
+	}
+	numOptions, cOptions := newCupsOptions(options)
+	defer C.cupsFreeOptions(numOptions, cOptions)
+	cOption := C.CString(option)
+	defer C.free(unsafe.Pointer(cOption))
+	cValue := C.CString(value)
+	defer C.free(unsafe.Pointer(cValue))
+
+	var numConflicts, numResolved C.int
+	var conflicts, resolved *C.cups_option_t
+	res := C.cupsCopyDestConflicts(p.http, p.dest, p.dinfo, numOptions, cOptions,
+		cOption, cValue, &numConflicts, &conflicts, &numResolved, &resolved)
+	defer C.cupsFreeOptions(numConflicts, conflicts)
+	defer C.cupsFreeOptions(numResolved, resolved)
+	switch {
+	case res < 0:
+		return OptionConflicts{}, lastCupsError()
+	case res == 0:
+		return OptionConflicts{}, nil
+	}
+	result := OptionConflicts{Conflicting: newJobOptions(numConflicts, conflicts)}
+	if numResolved > 0 {
+		result.Resolved = newJobOptions(numResolved, resolved)
+	}
+	return result, nil
+}
+
+// JobOptionValues returns the values that the printer supports for an IPP job option,
+// its default value, and the values that are ready without user intervention, using
+// cupsFindDestSupported, cupsFindDestDefault and cupsFindDestReady.
+//
+// Params:
+//
+//	option is the IPP name of the job option, for example OptionSides.
+func (p *Printer) JobOptionValues(option string) OptionValues {
+	if p.virtual != nil {
+		return virtualOptions[option]
+	}
+	if p.dinfo == nil {
+		return OptionValues{}
+	}
+	cOption := C.CString(option)
+	defer C.free(unsafe.Pointer(cOption))
+	values := OptionValues{
+		Supported: ippValueStrings(C.cupsFindDestSupported(p.http, p.dest, p.dinfo,
+			cOption), option),
+		Ready: ippValueStrings(C.cupsFindDestReady(p.http, p.dest, p.dinfo, cOption),
+			option),
+	}
+	if defaults := ippValueStrings(C.cupsFindDestDefault(p.http, p.dest, p.dinfo,
+		cOption), option); len(defaults) > 0 {
+		values.Default = defaults[0]
+	}
+	return values
+}
+
+// ippValueStrings returns the string form of each value of an IPP attribute. Enum
+// values are converted to the keywords of the option, and resolutions are written in
+// the form "600dpi" or "600x1200dpi".
+func ippValueStrings(attr *C.ipp_attribute_t, option string) []string {
+	if attr == nil {
+		return nil
+	}
+	cOption := C.CString(option)
+	defer C.free(unsafe.Pointer(cOption))
+	var values []string
+	for i := C.int(0); i < C.ippGetCount(attr); i++ {
+		switch C.ippGetValueTag(attr) {
+		case C.IPP_TAG_INTEGER:
+			values = append(values, strconv.Itoa(int(C.ippGetInteger(attr, i))))
+		case C.IPP_TAG_ENUM:
+			values = append(values,
+				C.GoString(C.ippEnumString(cOption, C.ippGetInteger(attr, i))))
+		case C.IPP_TAG_BOOLEAN:
+			values = append(values, strconv.FormatBool(C.ippGetBoolean(attr, i) != 0))
+		case C.IPP_TAG_RESOLUTION:
+			var y C.int
+			var units C.ipp_res_t
+			x := C.ippGetResolution(attr, i, &y, &units)
+			unit := "dpi"
+			if units == C.IPP_RES_PER_CM {
+				unit = "dpcm"
+			}
+			if x == y {
+				values = append(values, fmt.Sprintf("%d%s", x, unit))
+			} else {
+				values = append(values, fmt.Sprintf("%dx%d%s", x, y, unit))
+			}
+		default:
+			values = append(values, C.GoString(C.ippGetString(attr, i, nil)))
+		}
+	}
+	return values
+}
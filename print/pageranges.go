@@ -0,0 +1,152 @@
+package print
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+)
+
+// PageSet selects the odd or even pages of a page range selection.
+type PageSet int
+
+const (
+	AllPages  PageSet = iota // every selected page
+	OddPages                 // only the odd numbered pages
+	EvenPages                // only the even numbered pages
+)
+
+// PageRange is an inclusive range of 1-based page numbers.
+type PageRange struct {
+	First int
+	Last  int
+}
+
+// PageRanges selects the document pages that are printed.
+type PageRanges struct {
+	// Ranges are the page ranges to print, in the order that they are printed. No ranges
+	// selects every page.
+	Ranges []PageRange
+	// Set limits the selection to the odd or even pages.
+	Set PageSet
+	// Reverse prints the selected pages in reverse order.
+	Reverse bool
+}
+
+// ParsePageRanges parses page ranges as typed by users, such as "1-3, 5, 8-". A range
+// with no first page starts at page 1 and a range with no last page ends at the last
+// page of the document. An empty string selects every page.
+//
+// Params:
+//
+//	text is the page ranges to parse.
+//	pageCount is the number of pages in the document. Ranges must be within the document.
+func ParsePageRanges(text string, pageCount int) (PageRanges, error) {
+	var pr PageRanges
+	if strings.TrimSpace(text) == "" {
+		return pr, nil
+	}
+	for _, part := range strings.Split(text, ",") {
+		part = strings.TrimSpace(part)
+		first, last, isRange := strings.Cut(part, "-")
+		r := PageRange{First: 1, Last: pageCount}
+		var err error
+		if first = strings.TrimSpace(first); first != "" || !isRange {
+			if r.First, err = strconv.Atoi(first); err != nil {
+				return PageRanges{}, fmt.Errorf("invalid page range: %q", part)
+			}
+		}
+		if !isRange {
+			r.Last = r.First
+		} else if last = strings.TrimSpace(last); last != "" {
+			if r.Last, err = strconv.Atoi(last); err != nil {
+				return PageRanges{}, fmt.Errorf("invalid page range: %q", part)
+			}
+		}
+		if r.First < 1 || r.Last > pageCount {
+			return PageRanges{}, fmt.Errorf("page range %q is outside of pages 1 to %d",
+				part, pageCount)
+		}
+		if r.First > r.Last {
+			return PageRanges{}, fmt.Errorf("page range %q ends before it starts", part)
+		}
+		pr.Ranges = append(pr.Ranges, r)
+	}
+	return pr, nil
+}
+
+// CurrentPage returns the PageRanges that selects only the specified page.
+func CurrentPage(page int) PageRanges {
+	return PageRanges{Ranges: []PageRange{{First: page, Last: page}}}
+}
+
+// IsAll returns true if every page of a document is printed in order.
+func (pr PageRanges) IsAll() bool {
+	return len(pr.Ranges) == 0 && pr.Set == AllPages && !pr.Reverse
+}
+
+// String returns the page ranges in the form used by the IPP "page-ranges" option,
+// for example "1-3,5,8-10".
+func (pr PageRanges) String() string {
+	parts := make([]string, len(pr.Ranges))
+	for i, r := range pr.Ranges {
+		parts[i] = strconv.Itoa(r.First)
+		if r.Last != r.First {
+			parts[i] += "-" + strconv.Itoa(r.Last)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// ippValue returns the value of the IPP "page-ranges" attribute for the selection. The
+// attribute can only express ascending, non-overlapping ranges, so false is returned
+// if the selection must be applied client-side.
+func (pr PageRanges) ippValue() (string, bool) {
+	if len(pr.Ranges) == 0 || pr.Set != AllPages || pr.Reverse {
+		return "", false
+	}
+	for i := 1; i < len(pr.Ranges); i++ {
+		if pr.Ranges[i].First <= pr.Ranges[i-1].Last {
+			return "", false
+		}
+	}
+	return pr.String(), true
+}
+
+// Pages returns the 1-based numbers of the selected pages in the order that they are
+// printed. Ranges that extend past the end of the document are truncated.
+func (pr PageRanges) Pages(pageCount int) []int {
+	ranges := pr.Ranges
+	if len(ranges) == 0 {
+		ranges = []PageRange{{First: 1, Last: pageCount}}
+	}
+	var pages []int
+	for _, r := range ranges {
+		for page := r.First; page <= r.Last && page <= pageCount; page++ {
+			if page < 1 || (pr.Set == OddPages && page%2 == 0) ||
+				(pr.Set == EvenPages && page%2 == 1) {
+				continue
+			}
+			pages = append(pages, page)
+		}
+	}
+	if pr.Reverse {
+		for i, j := 0, len(pages)-1; i < j; i, j = i+1, j-1 {
+			pages[i], pages[j] = pages[j], pages[i]
+		}
+	}
+	return pages
+}
+
+// Select returns the selected pages in the order that they are printed.
+func (pr PageRanges) Select(pages []image.Image) []image.Image {
+	if pr.IsAll() {
+		return pages
+	}
+	numbers := pr.Pages(len(pages))
+	selected := make([]image.Image, len(numbers))
+	for i, page := range numbers {
+		selected[i] = pages[page-1]
+	}
+	return selected
+}
@@ -0,0 +1,85 @@
+package print
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePageRanges(t *testing.T) {
+	pr, err := ParsePageRanges("1-3, 5, 8-", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []PageRange{{1, 3}, {5, 5}, {8, 10}}, pr.Ranges)
+	assert.Equal(t, "1-3,5,8-10", pr.String())
+
+	pr, err = ParsePageRanges("-2", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []PageRange{{1, 2}}, pr.Ranges)
+
+	pr, err = ParsePageRanges(" ", 10)
+	assert.Nil(t, err)
+	assert.True(t, pr.IsAll())
+
+	for _, text := range []string{"0", "11", "4-2", "a", "1-b", "1,,2", "3-12"} {
+		_, err = ParsePageRanges(text, 10)
+		assert.NotNil(t, err, text)
+	}
+}
+
+func TestPageRanges_Pages(t *testing.T) {
+	pr := PageRanges{Ranges: []PageRange{{1, 3}, {6, 8}}}
+	assert.Equal(t, []int{1, 2, 3, 6, 7}, pr.Pages(7))
+	pr.Set = OddPages
+	assert.Equal(t, []int{1, 3, 7}, pr.Pages(7))
+	pr.Set = EvenPages
+	pr.Reverse = true
+	assert.Equal(t, []int{6, 2}, pr.Pages(7))
+	assert.Equal(t, []int{4, 3, 2, 1}, PageRanges{Reverse: true}.Pages(4))
+	assert.Equal(t, []int{3}, CurrentPage(3).Pages(4))
+}
+
+func TestPageRanges_IPPValue(t *testing.T) {
+	value, ok := PageRanges{Ranges: []PageRange{{1, 3}, {5, 5}}}.ippValue()
+	assert.True(t, ok)
+	assert.Equal(t, "1-3,5", value)
+	_, ok = PageRanges{Ranges: []PageRange{{5, 5}, {1, 3}}}.ippValue()
+	assert.False(t, ok)
+	_, ok = PageRanges{Ranges: []PageRange{{1, 3}}, Set: OddPages}.ippValue()
+	assert.False(t, ok)
+	_, ok = PageRanges{}.ippValue()
+	assert.False(t, ok)
+}
+
+func TestPageRanges_Select(t *testing.T) {
+	pages := []image.Image{
+		newTestPage(1, 1, color.Black),
+		newTestPage(2, 2, color.Black),
+		newTestPage(3, 3, color.Black),
+	}
+	selected := PageRanges{Ranges: []PageRange{{2, 3}}, Reverse: true}.Select(pages)
+	assert.Equal(t, []image.Image{pages[2], pages[1]}, selected)
+	assert.Equal(t, pages, PageRanges{}.Select(pages))
+}
+
+func TestPrintOperation_PrinterPageRanges(t *testing.T) {
+	po := &PrintOperation{
+		pageRanges: PageRanges{Ranges: []PageRange{{2, 3}}},
+		copies:     Copies{Count: 2},
+	}
+	// the pages are selected in the document when the copies are made in the document
+	_, ok := po.printerPageRanges(false)
+	assert.False(t, ok)
+	value, ok := po.printerPageRanges(true)
+	assert.True(t, ok)
+	assert.Equal(t, "2-3", value)
+
+	po.pageSetupInfo = &PageSetupInfo{nUp: NUp{PagesPerSheet: 2}}
+	_, ok = po.printerPageRanges(true)
+	assert.False(t, ok)
+	po.pageSetupInfo = nil
+	po.manualDuplex = &ManualDuplex{}
+	_, ok = po.printerPageRanges(true)
+	assert.False(t, ok)
+}
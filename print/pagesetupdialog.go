@@ -1,7 +1,11 @@
 package print
 
 import (
+	"image/color"
+	"strconv"
+
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
@@ -11,9 +15,99 @@ import (
 // PageSetupInfo contains information used to initialize the widgets in the PageSetupDialog and
 // to return data from it.
 type PageSetupInfo struct {
-	//	printer     *Printer
-	//	mediaSize   *MediaSize
+	printer     *Printer
+	mediaSize   *MediaSize
 	orientation string
+	nUp         NUp
+	booklet     *Booklet
+	scaling     Scaling
+	docSize     fyne.Size
+	context     *PrintContext
+	// changed is called when the printer or print context is set, so that an open
+	// PageSetupDialog shows them.
+	changed func()
+}
+
+// Printer returns the printer that the job is formatted for, or nil if none is set.
+func (psi *PageSetupInfo) Printer() *Printer {
+	return psi.printer
+}
+
+// SetPrinter sets the printer that the job is formatted for. The PageSetupDialog
+// lists its media sizes, marking and preferring those that are loaded.
+func (psi *PageSetupInfo) SetPrinter(p *Printer) {
+	psi.printer = p
+	psi.notifyChanged()
+}
+
+// MediaSize returns the media size selected for the print job, or nil if none is
+// selected.
+func (psi *PageSetupInfo) MediaSize() *MediaSize {
+	return psi.mediaSize
+}
+
+// SetMediaSize sets the media size for the print job. The PageSetupDialog selects it if
+// the printer has a media size with the same name.
+func (psi *PageSetupInfo) SetMediaSize(m *MediaSize) {
+	psi.mediaSize = m
+}
+
+// NUp returns the N-up imposition selected for the print job.
+func (psi *PageSetupInfo) NUp() NUp {
+	return psi.nUp
+}
+
+// SetNUp sets the N-up imposition for the print job.
+func (psi *PageSetupInfo) SetNUp(n NUp) {
+	psi.nUp = n
+}
+
+// Booklet returns the booklet imposition for the print job, or nil if the job is not
+// printed as a booklet.
+func (psi *PageSetupInfo) Booklet() *Booklet {
+	return psi.booklet
+}
+
+// SetBooklet sets the booklet imposition for the print job. Booklet printing replaces
+// the N-up setting. Pass nil to turn booklet printing off.
+func (psi *PageSetupInfo) SetBooklet(b *Booklet) {
+	psi.booklet = b
+}
+
+// Scaling returns how document pages are scaled onto the media.
+func (psi *PageSetupInfo) Scaling() Scaling {
+	return psi.scaling
+}
+
+// SetScaling sets how document pages are scaled onto the media.
+func (psi *PageSetupInfo) SetScaling(s Scaling) {
+	psi.scaling = s
+}
+
+// DocumentPageSize returns the size of the document's pages. A zero size means that
+// the document is paginated at the size of the media and is not scaled.
+func (psi *PageSetupInfo) DocumentPageSize() fyne.Size {
+	return psi.docSize
+}
+
+// SetDocumentPageSize sets the size of the document's pages.
+func (psi *PageSetupInfo) SetDocumentPageSize(size fyne.Size) {
+	psi.docSize = size
+}
+
+// SetPrintContext sets the print context for the media that the job is printed on.
+// It is used to preview the scaling in the PageSetupDialog.
+func (psi *PageSetupInfo) SetPrintContext(pc *PrintContext) {
+	psi.context = pc
+	psi.notifyChanged()
+}
+
+// notifyChanged tells the PageSetupDialog, if there is one, that the PageSetupInfo has
+// changed.
+func (psi *PageSetupInfo) notifyChanged() {
+	if psi.changed != nil {
+		psi.changed()
+	}
 }
 
 // NewPageSetupInfo creates a PageSetupInfo object.
@@ -45,15 +139,44 @@ type PageSetupDialog struct {
 	location              *widget.Label
 	comment               *widget.Label
 	paperSizeSelect       *widget.Select
+	trayLabel             *widget.Label
+	mediaSizes            MediaSizes
+	readyMedia            []ReadyMedia
 	orientationRadioGroup *widget.RadioGroup
+	pagesPerSheetSelect   *widget.Select
+	pageOrderSelect       *widget.Select
+	bordersCheck          *widget.Check
+	bookletCheck          *widget.Check
+	scalingSelect         *widget.Select
+	centerCheck           *widget.Check
+	scalingPreview        *fyne.Container
 }
 
+// nUpOrderNames are the page order names displayed in the PageSetupDialog, in
+// NUpOrder order.
+var nUpOrderNames = []string{"Left to Right, Top to Bottom", "Top to Bottom, Left to Right",
+	"Right to Left, Top to Bottom", "Top to Bottom, Right to Left"}
+
+// scalingNames are the scaling mode names displayed in the PageSetupDialog, in
+// PrintScaling order.
+var scalingNames = []string{"Automatic", "Shrink to Fit if Larger", "Fit to Printable Area",
+	"Fill Page", "Actual Size"}
+
+// scalingPreviewSize is the size of the scaling preview in the PageSetupDialog.
+const scalingPreviewSize = 100
+
 // NewPageSetupDialog creates a PageSetupDialog which is a ConfirmDialog.
 //
 // Params:
 //
 //	parent is the parent window for the dialog.
 func NewPageSetupDialog(parent fyne.Window, psInfo *PageSetupInfo) *dialog.ConfirmDialog {
+	return newPageSetupDialog(parent, psInfo).ConfirmDialog
+}
+
+// newPageSetupDialog creates the PageSetupDialog that NewPageSetupDialog returns. The
+// dialog is updated when the printer or print context of the PageSetupInfo is set.
+func newPageSetupDialog(parent fyne.Window, psInfo *PageSetupInfo) *PageSetupDialog {
 	psd := &PageSetupDialog{}
 	if psInfo == nil {
 		psInfo = &PageSetupInfo{}
@@ -62,10 +185,17 @@ func NewPageSetupDialog(parent fyne.Window, psInfo *PageSetupInfo) *dialog.Confi
 	psd.parent = parent
 	printerContainer := psd.createPrinterContainer()
 	psd.ConfirmDialog = dialog.NewCustomConfirm("PageSetup", "OK",
-		"Cancel", printerContainer, func(bool) {}, parent)
+		"Cancel", printerContainer, psd.confirmed, parent)
 	psd.Resize(fyne.NewSize(500, 300))
-	return psd.ConfirmDialog
+	psInfo.changed = psd.pageSetupInfoChanged
+	return psd
+}
 
+// pageSetupInfoChanged lists the media sizes of the PageSetupInfo's printer, and
+// redraws the scaling preview for its print context.
+func (psd *PageSetupDialog) pageSetupInfoChanged() {
+	psd.populatePaperSizes()
+	psd.updateScalingPreview()
 }
 
 // createPrinterContainer creates the container that holds the printers select and label.
@@ -78,21 +208,224 @@ func (psd *PageSetupDialog) createPrinterContainer() *fyne.Container {
 	commentLabel := widget.NewLabel("Comment")
 	psd.comment = widget.NewLabel("")
 	psLabel := widget.NewLabel("Paper Size")
-	psd.paperSizeSelect = widget.NewSelect([]string{}, nil)
+	psd.paperSizeSelect = widget.NewSelect([]string{}, func(string) { psd.paperSizeSelected() })
 	psd.paperSizeSelect.Alignment = fyne.TextAlignTrailing
+	trayLabel := widget.NewLabel("Tray")
+	psd.trayLabel = widget.NewLabel("")
 	orLabel := widget.NewLabel("Orientation")
 	psd.orientationRadioGroup = widget.NewRadioGroup([]string{"Portrait", "Landscape"}, nil)
 	psd.orientationRadioGroup.Horizontal = true
+	ppsLabel := widget.NewLabel("Pages per Sheet")
+	var ppsOptions []string
+	for _, n := range NUpPagesPerSheet {
+		ppsOptions = append(ppsOptions, strconv.Itoa(n))
+	}
+	psd.pagesPerSheetSelect = widget.NewSelect(ppsOptions, nil)
+	psd.pagesPerSheetSelect.Alignment = fyne.TextAlignTrailing
+	poLabel := widget.NewLabel("Page Order")
+	psd.pageOrderSelect = widget.NewSelect(nUpOrderNames, nil)
+	psd.pageOrderSelect.Alignment = fyne.TextAlignTrailing
+	psd.bordersCheck = widget.NewCheck("Print page borders", nil)
+	psd.bookletCheck = widget.NewCheck("Print as booklet", psd.bookletChecked)
+	scLabel := widget.NewLabel("Scaling")
+	psd.scalingSelect = widget.NewSelect(scalingNames, func(string) { psd.updateScalingPreview() })
+	psd.scalingSelect.Alignment = fyne.TextAlignTrailing
+	psd.centerCheck = widget.NewCheck("Center on page", func(bool) { psd.updateScalingPreview() })
+	psd.scalingPreview = container.NewWithoutLayout()
 	psd.populatePrinterSelect(psd.parent)
+	psd.populatePaperSizes()
+	psd.populateNUp()
+	psd.populateScaling()
 	prC := container.New(xlayout.NewHPortion([]float64{30, 70}), prLabel, psd.printerSelect)
 	prLocC := container.New(xlayout.NewHPortion([]float64{30, 70}), locLabel, psd.location)
 	prCommentC := container.New(xlayout.NewHPortion([]float64{30, 70}), commentLabel, psd.comment)
 	psC := container.New(xlayout.NewHPortion([]float64{30, 70}), psLabel, psd.paperSizeSelect)
+	trC := container.New(xlayout.NewHPortion([]float64{30, 70}), trayLabel, psd.trayLabel)
 	orC := container.New(xlayout.NewHPortion([]float64{30, 70}), orLabel, psd.orientationRadioGroup)
-	box := container.NewVBox(prC, prLocC, prCommentC, psC, orC)
+	ppsC := container.New(xlayout.NewHPortion([]float64{30, 70}), ppsLabel, psd.pagesPerSheetSelect)
+	poC := container.New(xlayout.NewHPortion([]float64{30, 70}), poLabel, psd.pageOrderSelect)
+	bC := container.New(xlayout.NewHPortion([]float64{30, 70}), widget.NewLabel(""), psd.bordersCheck)
+	blC := container.New(xlayout.NewHPortion([]float64{30, 70}), widget.NewLabel(""), psd.bookletCheck)
+	scC := container.New(xlayout.NewHPortion([]float64{30, 70}), scLabel, psd.scalingSelect)
+	ceC := container.New(xlayout.NewHPortion([]float64{30, 70}), widget.NewLabel(""), psd.centerCheck)
+	pvC := container.NewCenter(psd.scalingPreview)
+	box := container.NewVBox(prC, prLocC, prCommentC, psC, trC, orC, ppsC, poC, bC, blC, scC, ceC, pvC)
 	return box
 }
 
+// populatePaperSizes lists the media sizes of the PageSetupInfo's printer.
+func (psd *PageSetupDialog) populatePaperSizes() {
+	var sizes MediaSizes
+	var ready []ReadyMedia
+	if pr := psd.pageSetupInfo.printer; pr != nil {
+		sizes = pr.MediaSizes()
+		ready = pr.ReadyMedia()
+	}
+	psd.setMedia(sizes, ready)
+}
+
+// setMedia lists media sizes in the paper size select. Loaded media sizes are marked
+// with the tray that holds them. The PageSetupInfo's media size is selected if it is
+// listed, and otherwise the first loaded media size is selected.
+//
+// Params:
+//
+//	sizes are the printer's media sizes.
+//	ready are the media sizes that are loaded in the printer.
+func (psd *PageSetupDialog) setMedia(sizes MediaSizes, ready []ReadyMedia) {
+	psd.mediaSizes = sizes
+	psd.readyMedia = ready
+	options, preferred := mediaSizeOptions(sizes, ready)
+	if m := psd.pageSetupInfo.mediaSize; m != nil {
+		for i := range sizes {
+			if sizes[i].LocalName() == m.LocalName() {
+				preferred = i
+				break
+			}
+		}
+	}
+	if preferred < 0 && len(options) == 1 {
+		preferred = 0
+	}
+	psd.paperSizeSelect.Options = options
+	if preferred >= 0 {
+		psd.paperSizeSelect.SetSelectedIndex(preferred)
+	} else {
+		psd.paperSizeSelect.ClearSelected()
+	}
+	psd.paperSizeSelect.Refresh()
+	psd.paperSizeSelected()
+}
+
+// paperSizeSelected shows which tray holds the selected media size.
+func (psd *PageSetupDialog) paperSizeSelected() {
+	if psd.trayLabel == nil {
+		return
+	}
+	tray := "Not loaded"
+	if psd.readyMedia == nil {
+		tray = ""
+	}
+	if i := psd.paperSizeSelect.SelectedIndex(); i >= 0 && i < len(psd.mediaSizes) {
+		for _, r := range psd.readyMedia {
+			if r.LocalName() == psd.mediaSizes[i].LocalName() {
+				tray = r.Source
+				if tray == "" {
+					tray = "Loaded"
+				}
+			}
+		}
+	}
+	psd.trayLabel.SetText(tray)
+}
+
+// populateNUp sets the N-up widgets from the PageSetupInfo.
+func (psd *PageSetupDialog) populateNUp() {
+	n := psd.pageSetupInfo.nUp
+	if n.PagesPerSheet < 1 {
+		n.PagesPerSheet = 1
+	}
+	psd.pagesPerSheetSelect.SetSelected(strconv.Itoa(n.PagesPerSheet))
+	psd.pageOrderSelect.SetSelectedIndex(int(n.Order))
+	psd.bordersCheck.SetChecked(n.Border)
+	psd.bookletCheck.SetChecked(psd.pageSetupInfo.booklet != nil)
+}
+
+// populateScaling sets the scaling widgets from the PageSetupInfo.
+func (psd *PageSetupDialog) populateScaling() {
+	s := psd.pageSetupInfo.scaling
+	psd.scalingSelect.SetSelectedIndex(int(s.Mode))
+	psd.centerCheck.SetChecked(!s.TopLeft)
+	psd.updateScalingPreview()
+}
+
+// selectedScaling returns the scaling selected in the dialog.
+func (psd *PageSetupDialog) selectedScaling() Scaling {
+	s := psd.pageSetupInfo.scaling
+	if i := psd.scalingSelect.SelectedIndex(); i >= 0 {
+		s.Mode = PrintScaling(i)
+	}
+	s.TopLeft = !psd.centerCheck.Checked
+	return s
+}
+
+// updateScalingPreview draws the media, its imageable area, and the position of a
+// document page using the selected scaling.
+func (psd *PageSetupDialog) updateScalingPreview() {
+	if psd.scalingPreview == nil || psd.centerCheck == nil {
+		return
+	}
+	pc := psd.pageSetupInfo.context
+	if pc == nil {
+		pc = newPrintContext(fyne.NewSize(612, 792), Margins{top: 18, bottom: 18, left: 18,
+			right: 18}, pointsPerInch)
+	}
+	doc := psd.pageSetupInfo.docSize
+	if doc.IsZero() {
+		doc = pc.PageSize()
+	}
+	page := pc.PageSize()
+	k := scalingPreviewSize / maxFloat32(page.Width, page.Height)
+
+	paper := canvas.NewRectangle(color.White)
+	paper.StrokeColor = color.Gray{Y: 0x80}
+	paper.StrokeWidth = 1
+	paper.SetMinSize(fyne.NewSize(page.Width*k, page.Height*k))
+	paper.Resize(paper.MinSize())
+	imageable := canvas.NewRectangle(color.Transparent)
+	imageable.StrokeColor = color.Gray{Y: 0xc0}
+	imageable.StrokeWidth = 1
+	imageable.Move(fyne.NewPos(pc.ImageableOrigin().X*k, pc.ImageableOrigin().Y*k))
+	imageable.Resize(fyne.NewSize(pc.ImageableSize().Width*k, pc.ImageableSize().Height*k))
+	pos, size := psd.selectedScaling().rect(doc, pc)
+	content := canvas.NewRectangle(color.NRGBA{R: 0x40, G: 0x80, B: 0xc0, A: 0x80})
+	content.Move(fyne.NewPos(pos.X*k, pos.Y*k))
+	content.Resize(fyne.NewSize(size.Width*k, size.Height*k))
+
+	psd.scalingPreview.Objects = []fyne.CanvasObject{paper, imageable, content}
+	psd.scalingPreview.Resize(paper.Size())
+	psd.scalingPreview.Refresh()
+}
+
+// bookletChecked disables the N-up widgets while booklet printing is selected.
+func (psd *PageSetupDialog) bookletChecked(checked bool) {
+	for _, w := range []fyne.Disableable{psd.pagesPerSheetSelect, psd.pageOrderSelect,
+		psd.bordersCheck} {
+		if checked {
+			w.Disable()
+		} else {
+			w.Enable()
+		}
+	}
+}
+
+// confirmed saves the dialog's settings in the PageSetupInfo when OK is pressed.
+func (psd *PageSetupDialog) confirmed(ok bool) {
+	if !ok {
+		return
+	}
+	pps, err := strconv.Atoi(psd.pagesPerSheetSelect.Selected)
+	if err != nil {
+		pps = 1
+	}
+	psd.pageSetupInfo.nUp = NUp{
+		PagesPerSheet: pps,
+		Order:         NUpOrder(psd.pageOrderSelect.SelectedIndex()),
+		Border:        psd.bordersCheck.Checked,
+	}
+	psd.pageSetupInfo.scaling = psd.selectedScaling()
+	if i := psd.paperSizeSelect.SelectedIndex(); i >= 0 && i < len(psd.mediaSizes) {
+		m := psd.mediaSizes[i]
+		psd.pageSetupInfo.mediaSize = &m
+	}
+	switch {
+	case !psd.bookletCheck.Checked:
+		psd.pageSetupInfo.booklet = nil
+	case psd.pageSetupInfo.booklet == nil:
+		psd.pageSetupInfo.booklet = &Booklet{}
+	}
+}
+
 func (psd *PageSetupDialog) populatePrinterSelect(parent fyne.Window) {
 	/*	ps := NewPrinters()
 		if len(ps.Printers) == 0 {
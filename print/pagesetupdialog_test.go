@@ -0,0 +1,52 @@
+package print
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageSetupDialog_SetPrintContext(t *testing.T) {
+	test.NewApp()
+	po := &PrintOperation{pageSetupInfo: &PageSetupInfo{}}
+	psd := newPageSetupDialog(test.NewWindow(nil), po.pageSetupInfo)
+	// the preview is drawn on US Letter until a print context is set
+	paper := psd.scalingPreview.Objects[0]
+	assert.Less(t, paper.Size().Width, paper.Size().Height)
+
+	po.SetPrintContext(newPrintContext(fyne.NewSize(792, 612), Margins{}, pointsPerInch))
+	paper = psd.scalingPreview.Objects[0]
+	assert.InDelta(t, scalingPreviewSize, paper.Size().Width, 0.01)
+	assert.InDelta(t, 612.0*scalingPreviewSize/792, paper.Size().Height, 0.01)
+}
+
+func TestPageSetupDialog_ReadyMedia(t *testing.T) {
+	test.NewApp()
+	psInfo := &PageSetupInfo{}
+	psd := newPageSetupDialog(test.NewWindow(nil), psInfo)
+	assert.Empty(t, psd.paperSizeSelect.Options)
+
+	// setting the printer lists its media sizes
+	psInfo.SetPrinter(NewPDFPrinter(nil))
+	assert.Equal(t, len(standardMedia), len(psd.paperSizeSelect.Options))
+
+	var sizes MediaSizes
+	for _, m := range standardMedia[:3] {
+		sizes.Add(newStandardMediaSize(m))
+	}
+	psd.setMedia(sizes, []ReadyMedia{{MediaSize: sizes[1], Source: "tray-1"}})
+	assert.Equal(t, 1, psd.paperSizeSelect.SelectedIndex())
+	assert.Equal(t, "tray-1", psd.trayLabel.Text)
+	psd.paperSizeSelect.SetSelectedIndex(2)
+	assert.Equal(t, "Not loaded", psd.trayLabel.Text)
+
+	// the selected media size is saved, and selected when the media is listed again
+	psd.confirmed(true)
+	assert.Equal(t, "Executive", psInfo.MediaSize().LocalName())
+	psd.setMedia(sizes, []ReadyMedia{{MediaSize: sizes[1], Source: "tray-1"}})
+	assert.Equal(t, 2, psd.paperSizeSelect.SelectedIndex())
+	psd.confirmed(false)
+	assert.Equal(t, "Executive", psInfo.MediaSize().LocalName())
+}
@@ -0,0 +1,172 @@
+package print
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"strings"
+)
+
+// PCLFormat is the MIME type for PCL documents.
+const PCLFormat = "application/vnd.hp-pcl"
+
+// PCLDuplex is the value of the PCL simplex/duplex print command (ESC&l#S).
+type PCLDuplex int
+
+const (
+	PCLSimplex         PCLDuplex = 0 // one-sided printing
+	PCLDuplexLongEdge  PCLDuplex = 1 // two-sided, bound on the long edge
+	PCLDuplexShortEdge PCLDuplex = 2 // two-sided, bound on the short edge
+)
+
+// pclPageSizes maps the size portion of PWG media names to PCL page size codes.
+var pclPageSizes = map[string]int{
+	"na_executive": 1,
+	"na_letter":    2,
+	"na_legal":     3,
+	"na_ledger":    6,
+	"iso_a5":       25,
+	"iso_a4":       26,
+	"iso_a3":       27,
+	"jis_b5":       45,
+	"jis_b4":       46,
+	"na_monarch":   80,
+	"na_number-10": 81,
+	"iso_dl":       90,
+	"iso_c5":       91,
+	"iso_b5":       100,
+}
+
+// pclPageSizeFromPWG returns the PCL page size code for a PWG self-describing media
+// name such as "na_letter_8.5x11in". Zero is returned for sizes that PCL does not
+// define, in which case no page size command is sent and the printer's default is used.
+func pclPageSizeFromPWG(name string) int {
+	parts := strings.Split(name, "_")
+	if len(parts) < 2 {
+		return 0
+	}
+	return pclPageSizes[parts[0]+"_"+parts[1]]
+}
+
+// Declare conformity with PageEncoder interface
+var _ PageEncoder = (*PCLEncoder)(nil)
+
+// PCLEncoder writes rendered pages as a PCL 5 monochrome raster document. PCL 6
+// printers accept these documents through their PCL 5 personality.
+type PCLEncoder struct {
+	Resolution int
+	PageSize   int
+	Duplex     PCLDuplex
+	Copies     int
+}
+
+// NewPCLEncoder creates a PCLEncoder for the specified media size.
+//
+// Params:
+//
+//	ms is the media size that the document will be printed on.
+//	resolution is the raster resolution in dots per inch. It should be one of
+//	75, 100, 150, 200, 300, or 600.
+func NewPCLEncoder(ms *MediaSize, resolution int) *PCLEncoder {
+	return &PCLEncoder{
+		Resolution: resolution,
+		PageSize:   ms.pclPageSize(),
+		Duplex:     PCLSimplex,
+		Copies:     1,
+	}
+}
+
+// Format returns the MIME type of the documents created by the encoder.
+func (e *PCLEncoder) Format() string {
+	return PCLFormat
+}
+
+// Encode writes the pages to w. The document is wrapped in PJL universal exit language
+// commands so that it can be sent directly to a raw queue or port 9100.
+func (e *PCLEncoder) Encode(w io.Writer, pages []image.Image) error {
+	if len(pages) == 0 {
+		return errors.New("no pages to encode")
+	}
+	var buf bytes.Buffer
+	buf.WriteString("\x1b%-12345X@PJL ENTER LANGUAGE=PCL\r\n")
+	buf.WriteString("\x1bE")
+	if e.Copies > 1 {
+		fmt.Fprintf(&buf, "\x1b&l%dX", e.Copies)
+	}
+	fmt.Fprintf(&buf, "\x1b&l%dS", e.Duplex)
+	if e.PageSize != 0 {
+		fmt.Fprintf(&buf, "\x1b&l%dA", e.PageSize)
+	}
+	buf.WriteString("\x1b&l0O\x1b&l0E")
+	fmt.Fprintf(&buf, "\x1b*t%dR", e.Resolution)
+	for _, page := range pages {
+		e.encodePage(&buf, page)
+	}
+	buf.WriteString("\x1bE\x1b%-12345X")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// encodePage writes a single page as a raster graphic compressed with TIFF PackBits
+// (compression mode 2), followed by a form feed.
+func (e *PCLEncoder) encodePage(buf *bytes.Buffer, page image.Image) {
+	b := page.Bounds()
+	buf.WriteString("\x1b*p0x0Y")
+	fmt.Fprintf(buf, "\x1b*r%dS\x1b*r%dT", b.Dx(), b.Dy())
+	buf.WriteString("\x1b*r1A\x1b*b2M")
+	for _, row := range monoRows(page) {
+		data := packBits(row)
+		fmt.Fprintf(buf, "\x1b*b%dW", len(data))
+		buf.Write(data)
+	}
+	buf.WriteString("\x1b*rC\f")
+}
+
+// monoRows converts a page image into rows of 1-bit pixels, with a set bit for each
+// black pixel. Pixels darker than mid-gray are printed.
+func monoRows(img image.Image) [][]byte {
+	gray := rasterRows(img, rasterGray8)
+	rows := make([][]byte, len(gray))
+	for y, g := range gray {
+		row := make([]byte, (len(g)+7)/8)
+		for x, v := range g {
+			if v < 0x80 {
+				row[x/8] |= 0x80 >> (x % 8)
+			}
+		}
+		rows[y] = row
+	}
+	return rows
+}
+
+// packBits compresses data using TIFF PackBits. A control byte of 0 to 127 is followed
+// by one to 128 literal bytes; a control byte of 129 to 255 indicates that the following
+// byte is repeated 128 to 2 times. Rows that are entirely blank compress to nothing.
+func packBits(data []byte) []byte {
+	if len(bytes.Trim(data, "\x00")) == 0 {
+		return nil
+	}
+	var out []byte
+	for i := 0; i < len(data); {
+		run := 1
+		for i+run < len(data) && run < 128 && data[i+run] == data[i] {
+			run++
+		}
+		if run > 1 {
+			out = append(out, byte(257-run), data[i])
+			i += run
+			continue
+		}
+		count := 1
+		for i+count < len(data) && count < 128 &&
+			(i+count == len(data)-1 || data[i+count] != data[i+count+1]) {
+			count++
+		}
+		out = append(out, byte(count-1))
+		out = append(out, data[i:i+count]...)
+		i += count
+	}
+	return out
+}
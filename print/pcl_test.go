@@ -0,0 +1,53 @@
+package print
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPCLPageSizeFromPWG(t *testing.T) {
+	assert.Equal(t, 2, pclPageSizeFromPWG("na_letter_8.5x11in"))
+	assert.Equal(t, 26, pclPageSizeFromPWG("iso_a4_210x297mm"))
+	assert.Equal(t, 81, pclPageSizeFromPWG("na_number-10_4.125x9.5in"))
+	assert.Equal(t, 0, pclPageSizeFromPWG("custom_min_1x1in"))
+	assert.Equal(t, 0, pclPageSizeFromPWG("letter"))
+}
+
+func TestPackBits(t *testing.T) {
+	assert.Nil(t, packBits([]byte{0, 0, 0}))
+	assert.Equal(t, []byte{253, 0xff, 1, 1, 2}, packBits([]byte{0xff, 0xff, 0xff, 0xff, 1, 2}))
+	assert.Equal(t, []byte{0, 1, 255, 2}, packBits([]byte{1, 2, 2}))
+}
+
+func TestMonoRows(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 10, 1))
+	for x := 0; x < 10; x++ {
+		img.SetGray(x, 0, color.Gray{Y: 0xff})
+	}
+	img.SetGray(0, 0, color.Gray{Y: 0})
+	img.SetGray(9, 0, color.Gray{Y: 0x20})
+	assert.Equal(t, [][]byte{{0x80, 0x40}}, monoRows(img))
+}
+
+func TestPCLEncoder_Encode(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 8, 2))
+	e := &PCLEncoder{Resolution: 300, PageSize: 26, Duplex: PCLDuplexLongEdge, Copies: 2}
+	var buf bytes.Buffer
+	err := e.Encode(&buf, []image.Image{img, img})
+	assert.Nil(t, err)
+	s := buf.String()
+	assert.True(t, strings.HasPrefix(s, "\x1b%-12345X@PJL ENTER LANGUAGE=PCL\r\n\x1bE"))
+	assert.True(t, strings.HasSuffix(s, "\x1bE\x1b%-12345X"))
+	assert.Contains(t, s, "\x1b&l2X\x1b&l1S\x1b&l26A")
+	assert.Contains(t, s, "\x1b*t300R")
+	assert.Contains(t, s, "\x1b*r8S\x1b*r2T")
+	assert.Equal(t, 2, strings.Count(s, "\f"))
+	assert.Equal(t, 4, strings.Count(s, "\x1b*b2W\x00\xff"))
+
+	assert.NotNil(t, e.Encode(&buf, nil))
+}
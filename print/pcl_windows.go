@@ -0,0 +1,42 @@
+package print
+
+//#define UNICODE
+//#include "windows.h"
+import "C"
+
+// pclPageSize returns the PCL page size code for the media size, or 0 if PCL does
+// not define the size.
+func (ms *MediaSize) pclPageSize() int {
+	switch ms.paperSize {
+	case C.DMPAPER_EXECUTIVE:
+		return 1
+	case C.DMPAPER_LETTER:
+		return 2
+	case C.DMPAPER_LEGAL:
+		return 3
+	case C.DMPAPER_LEDGER, C.DMPAPER_TABLOID:
+		return 6
+	case C.DMPAPER_A5:
+		return 25
+	case C.DMPAPER_A4:
+		return 26
+	case C.DMPAPER_A3:
+		return 27
+	case C.DMPAPER_B5:
+		return 45
+	case C.DMPAPER_B4:
+		return 46
+	case C.DMPAPER_ENV_MONARCH:
+		return 80
+	case C.DMPAPER_ENV_10:
+		return 81
+	case C.DMPAPER_ENV_DL:
+		return 90
+	case C.DMPAPER_ENV_C5:
+		return 91
+	case C.DMPAPER_ENV_B5:
+		return 100
+	default:
+		return 0
+	}
+}
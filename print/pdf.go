@@ -0,0 +1,100 @@
+package print
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"io"
+)
+
+// PDFFormat is the MIME type of PDF documents.
+const PDFFormat = "application/pdf"
+
+// PDFEncoder writes pages as a PDF document. Each page is stored as a compressed image
+// that fills a PDF page of the same physical size.
+type PDFEncoder struct {
+	// Resolution is the resolution of the rendered pages in dots per inch. It sets the
+	// size of the PDF pages.
+	Resolution int
+}
+
+// Format returns the MIME type of PDF documents.
+func (e *PDFEncoder) Format() string {
+	return PDFFormat
+}
+
+// Encode writes the pages to w as a PDF document. Grayscale pages are stored in the
+// DeviceGray color space and all other pages in DeviceRGB. Transparent pixels are
+// composited onto white paper.
+func (e *PDFEncoder) Encode(w io.Writer, pages []image.Image) error {
+	var buf bytes.Buffer
+	var offsets []int
+	// object numbers: 1 is the catalog, 2 is the page tree, and each page uses three
+	// objects for the page, its content stream, and its image
+	startObject := func() int {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n", len(offsets))
+		return len(offsets)
+	}
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	startObject()
+	buf.WriteString("<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	startObject()
+	buf.WriteString("<< /Type /Pages /Kids [")
+	for i := range pages {
+		fmt.Fprintf(&buf, " %d 0 R", 3+3*i)
+	}
+	fmt.Fprintf(&buf, " ] /Count %d >>\nendobj\n", len(pages))
+
+	dpi := e.Resolution
+	if dpi <= 0 {
+		dpi = 300
+	}
+	for _, page := range pages {
+		b := page.Bounds()
+		width := float64(b.Dx()) * pointsPerInch / float64(dpi)
+		height := float64(b.Dy()) * pointsPerInch / float64(dpi)
+		obj := startObject()
+		fmt.Fprintf(&buf, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /XObject << /Im0 %d 0 R >> >> /Contents %d 0 R >>\nendobj\n",
+			width, height, obj+2, obj+1)
+
+		content := fmt.Sprintf("q %.2f 0 0 %.2f 0 0 cm /Im0 Do Q", width, height)
+		startObject()
+		fmt.Fprintf(&buf, "<< /Length %d >>\nstream\n%s\nendstream\nendobj\n",
+			len(content), content)
+
+		cs, colorSpace := rasterRGB24, "/DeviceRGB"
+		if _, ok := page.(*image.Gray); ok {
+			cs, colorSpace = rasterGray8, "/DeviceGray"
+		}
+		var data bytes.Buffer
+		zw := zlib.NewWriter(&data)
+		for _, row := range rasterRows(page, cs) {
+			if _, err := zw.Write(row); err != nil {
+				return err
+			}
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		startObject()
+		fmt.Fprintf(&buf, "<< /Type /XObject /Subtype /Image /Width %d /Height %d "+
+			"/ColorSpace %s /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\n"+
+			"stream\n", b.Dx(), b.Dy(), colorSpace, data.Len())
+		buf.Write(data.Bytes())
+		buf.WriteString("\nendstream\nendobj\n")
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1, xref)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
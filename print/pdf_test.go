@@ -0,0 +1,40 @@
+package print
+
+import (
+	"bytes"
+	"compress/zlib"
+	"image"
+	"image/color"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPDFEncoder_Encode(t *testing.T) {
+	gray := image.NewGray(image.Rect(0, 0, 150, 300))
+	pages := []image.Image{newTestPage(300, 600, color.Black), gray}
+	var buf bytes.Buffer
+	e := &PDFEncoder{Resolution: 150}
+	assert.Equal(t, PDFFormat, e.Format())
+	assert.Nil(t, e.Encode(&buf, pages))
+
+	pdf := buf.String()
+	assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+	assert.Contains(t, pdf, "/Kids [ 3 0 R 6 0 R ] /Count 2")
+	assert.Contains(t, pdf, "/MediaBox [0 0 144.00 288.00]")
+	assert.Contains(t, pdf, "/MediaBox [0 0 72.00 144.00]")
+	assert.Contains(t, pdf, "/Width 300 /Height 600 /ColorSpace /DeviceRGB")
+	assert.Contains(t, pdf, "/Width 150 /Height 300 /ColorSpace /DeviceGray")
+	assert.Contains(t, pdf, "xref\n0 9\n")
+
+	// the first image stream holds the black page
+	start := strings.Index(pdf, "stream\nx") + len("stream\n")
+	r, err := zlib.NewReader(strings.NewReader(pdf[start:]))
+	assert.Nil(t, err)
+	pixels, _ := io.ReadAll(r)
+	assert.Equal(t, 300*600*3, len(pixels))
+	assert.Equal(t, byte(0), pixels[0])
+}
@@ -0,0 +1,161 @@
+package print
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+)
+
+// posterCropMarkLength is the length, in fyne units, of the crop marks printed on
+// poster tiles.
+const posterCropMarkLength = 12
+
+// posterLabelSize is the text size of the labels printed on poster tiles.
+const posterLabelSize = 8
+
+// Poster describes how a drawing that is larger than the media is split into tiles
+// that are printed on separate sheets and then trimmed and joined.
+type Poster struct {
+	// Columns and Rows are the number of sheets across and down. If either is zero, the
+	// drawing is printed at full size on as many sheets as it needs. Otherwise, the
+	// drawing is scaled to fit the sheets.
+	Columns int
+	Rows    int
+	// Overlap is the amount, in fyne units, that adjacent tiles overlap. The overlap
+	// on the top and left of each tile is cut off when the tiles are joined.
+	Overlap float32
+	// CropMarks prints marks at the corners of the part of each tile that is kept.
+	CropMarks bool
+	// Labels prints the row and column of each tile below the tile.
+	Labels bool
+}
+
+// Render renders a drawing and splits it into tiles.
+//
+// Params:
+//
+//	obj is the drawing to print.
+//	size is the size of the drawing.
+//	pc is the print context for the sheets that the tiles are printed on.
+func (p Poster) Render(obj fyne.CanvasObject, size fyne.Size, pc *PrintContext) []image.Image {
+	return p.Tile(renderObject(obj, size, pc.DPI()), pc)
+}
+
+// Tile splits a drawing into tiles, one per sheet. The tiles are returned in row
+// order, starting at the top left of the drawing.
+//
+// Params:
+//
+//	drawing is the drawing, rendered at the print context's resolution.
+//	pc is the print context for the sheets that the tiles are printed on.
+func (p Poster) Tile(drawing image.Image, pc *PrintContext) []image.Image {
+	imageable := pc.ImageableSize()
+	if p.Labels {
+		imageable.Height -= p.labelHeight()
+	}
+	tile := image.Pt(pc.toPixels(imageable.Width), pc.toPixels(imageable.Height))
+	overlap := pc.toPixels(p.Overlap)
+	step := tile.Sub(image.Pt(overlap, overlap))
+	if step.X <= 0 || step.Y <= 0 {
+		return nil
+	}
+
+	cols, rows := p.Columns, p.Rows
+	if cols > 0 && rows > 0 {
+		drawing = p.scaled(drawing, image.Pt(cols*step.X+overlap, rows*step.Y+overlap))
+	} else {
+		size := drawing.Bounds().Size()
+		cols = tileCount(size.X, step.X, overlap)
+		rows = tileCount(size.Y, step.Y, overlap)
+	}
+
+	origin := image.Pt(pc.toPixels(pc.margins.left), pc.toPixels(pc.margins.top))
+	var tiles []image.Image
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			page := pc.newPageImage()
+			src := drawing.Bounds().Min.Add(image.Pt(c*step.X, r*step.Y))
+			draw.Draw(page, image.Rectangle{Min: origin, Max: origin.Add(tile)}, drawing, src,
+				draw.Over)
+			if p.CropMarks {
+				trim := image.Rectangle{Min: origin, Max: origin.Add(tile)}
+				if c > 0 {
+					trim.Min.X += overlap
+				}
+				if r > 0 {
+					trim.Min.Y += overlap
+				}
+				drawCropMarks(page, trim, image.Rectangle{Min: origin, Max: origin.Add(tile)},
+					pc.toPixels(posterCropMarkLength))
+			}
+			if p.Labels {
+				at := pc.ImageableOrigin().AddXY(0, imageable.Height+headerFooterGap/2)
+				p.drawLabel(page, pc, fmt.Sprintf("Row %d, Column %d", r+1, c+1), at)
+			}
+			tiles = append(tiles, page)
+		}
+	}
+	return tiles
+}
+
+// scaled returns the drawing scaled to fit a poster of the specified size in pixels.
+func (p Poster) scaled(drawing image.Image, size image.Point) image.Image {
+	dst := image.NewNRGBA(image.Rectangle{Max: size})
+	drawFitted(dst, dst.Bounds(), drawing)
+	return dst
+}
+
+// labelHeight returns the height of the band that the tile labels are printed in.
+func (p Poster) labelHeight() float32 {
+	t := canvas.NewText("Xg", color.Black)
+	t.TextSize = posterLabelSize
+	return t.MinSize().Height + headerFooterGap
+}
+
+// drawLabel draws a tile label on a page.
+func (p Poster) drawLabel(page draw.Image, pc *PrintContext, label string, at fyne.Position) {
+	t := canvas.NewText(label, color.Black)
+	t.TextSize = posterLabelSize
+	t.Resize(t.MinSize())
+	pc.renderRegion(page, t, t.MinSize(), fyne.NewPos(0, 0), at)
+}
+
+// tileCount returns the number of tiles needed to cover length pixels when each tile
+// advances by step pixels and overlaps the next by overlap pixels.
+func tileCount(length, step, overlap int) int {
+	n := (length - overlap + step - 1) / step
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// drawCropMarks draws marks on the lines through the corners of trim. Each mark extends
+// outwards from its corner into the part of bounds that is cut off, such as the overlap
+// of a poster tile. Where bounds has no room outside trim, the mark extends inwards
+// along the edge of trim instead.
+func drawCropMarks(dst draw.Image, trim, bounds image.Rectangle, length int) {
+	line := func(out, in image.Rectangle) {
+		r := out.Intersect(bounds)
+		if r.Empty() {
+			r = in.Intersect(bounds)
+		}
+		draw.Draw(dst, r, image.Black, image.Point{}, draw.Src)
+	}
+	for _, x := range []int{trim.Min.X, trim.Max.X - 1} {
+		line(image.Rect(x, trim.Min.Y-length, x+1, trim.Min.Y),
+			image.Rect(x, trim.Min.Y, x+1, trim.Min.Y+length))
+		line(image.Rect(x, trim.Max.Y, x+1, trim.Max.Y+length),
+			image.Rect(x, trim.Max.Y-length, x+1, trim.Max.Y))
+	}
+	for _, y := range []int{trim.Min.Y, trim.Max.Y - 1} {
+		line(image.Rect(trim.Min.X-length, y, trim.Min.X, y+1),
+			image.Rect(trim.Min.X, y, trim.Min.X+length, y+1))
+		line(image.Rect(trim.Max.X, y, trim.Max.X+length, y+1),
+			image.Rect(trim.Max.X-length, y, trim.Max.X, y+1))
+	}
+}
@@ -0,0 +1,75 @@
+package print
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTileCount(t *testing.T) {
+	assert.Equal(t, 1, tileCount(50, 100, 0))
+	assert.Equal(t, 2, tileCount(200, 100, 0))
+	assert.Equal(t, 3, tileCount(201, 100, 0))
+	assert.Equal(t, 2, tileCount(210, 100, 10))
+	assert.Equal(t, 3, tileCount(211, 100, 10))
+}
+
+func TestPoster_Tile(t *testing.T) {
+	pc := newPrintContext(fyne.NewSize(72, 144), Margins{}, 72)
+	drawing := newTestPage(130, 200, color.Black)
+
+	tiles := Poster{}.Tile(drawing, pc)
+	assert.Len(t, tiles, 4)
+	assert.Equal(t, image.Rect(0, 0, 72, 144), tiles[0].Bounds())
+	// the last column only holds the rest of the drawing
+	assert.NotEqual(t, uint32(0), alphaAt(tiles[1], 57, 0))
+	assert.Equal(t, uint32(0), alphaAt(tiles[1], 58, 0))
+	assert.Equal(t, uint32(0), alphaAt(tiles[3], 0, 56))
+
+	tiles = Poster{Overlap: 10}.Tile(drawing, pc)
+	assert.Len(t, tiles, 4)
+	tiles = Poster{Columns: 3, Rows: 1}.Tile(drawing, pc)
+	assert.Len(t, tiles, 3)
+}
+
+func TestPoster_TileCropMarks(t *testing.T) {
+	pc := newPrintContext(fyne.NewSize(72, 72), Margins{top: 18, bottom: 18, left: 18, right: 18}, 72)
+	drawing := newTestPage(66, 36, color.White)
+	tiles := Poster{Overlap: 6, CropMarks: true}.Tile(drawing, pc)
+	assert.Len(t, tiles, 2)
+	r, _, _, _ := tiles[1].At(20, 18).RGBA()
+	assert.Equal(t, uint32(0), r)
+	r, _, _, _ = tiles[1].At(24, 30).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+}
+
+func TestPoster_TileCropMarksWithoutOverlap(t *testing.T) {
+	pc := newPrintContext(fyne.NewSize(72, 72), Margins{top: 18, bottom: 18, left: 18, right: 18}, 72)
+	drawing := newTestPage(72, 36, color.White)
+	tiles := Poster{CropMarks: true}.Tile(drawing, pc)
+	assert.Len(t, tiles, 2)
+	for _, tile := range tiles {
+		black := 0
+		b := tile.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				if r, _, _, a := tile.At(x, y).RGBA(); r == 0 && a != 0 {
+					black++
+				}
+			}
+		}
+		// two 12 pixel marks that share the corner pixel at each corner of the tile
+		assert.Equal(t, 4*23, black)
+	}
+}
+
+func TestPoster_Render(t *testing.T) {
+	test.NewApp()
+	pc := newPrintContext(fyne.NewSize(72, 72), Margins{}, 72)
+	tiles := Poster{Labels: true}.Render(newTestRect(100), fyne.NewSize(100, 100), pc)
+	assert.Len(t, tiles, 4)
+}
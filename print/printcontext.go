@@ -0,0 +1,103 @@
+package print
+
+import (
+	"image"
+	"image/draw"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+)
+
+// PrintContext describes the page that content is printed on. All sizes are in fyne
+// units, which are treated as points (1/72 inch) when printing.
+type PrintContext struct {
+	pageSize fyne.Size
+	margins  Margins
+	dpi      int
+}
+
+// NewPrintContext creates a PrintContext for a printer's media size.
+//
+// Params:
+//
+//	ms is the media size that will be printed on.
+//	dpi is the resolution that pages are rendered at.
+func NewPrintContext(ms *MediaSize, dpi int) *PrintContext {
+	return newPrintContext(ms.pageSize(), ms.pageMargins(), dpi)
+}
+
+// newPrintContext creates a PrintContext from a page size and margins in points.
+func newPrintContext(pageSize fyne.Size, margins Margins, dpi int) *PrintContext {
+	return &PrintContext{pageSize: pageSize, margins: margins, dpi: dpi}
+}
+
+// DPI returns the resolution that pages are rendered at.
+func (pc *PrintContext) DPI() int {
+	return pc.dpi
+}
+
+// PageSize returns the size of the whole page.
+func (pc *PrintContext) PageSize() fyne.Size {
+	return pc.pageSize
+}
+
+// Margins returns the unprintable margins of the page.
+func (pc *PrintContext) Margins() Margins {
+	return pc.margins
+}
+
+// ImageableOrigin returns the position of the top left corner of the imageable area
+// of the page.
+func (pc *PrintContext) ImageableOrigin() fyne.Position {
+	return fyne.NewPos(pc.margins.left, pc.margins.top)
+}
+
+// ImageableSize returns the size of the area of the page that can be printed on.
+func (pc *PrintContext) ImageableSize() fyne.Size {
+	return pc.pageSize.SubtractWidthHeight(pc.margins.left+pc.margins.right,
+		pc.margins.top+pc.margins.bottom)
+}
+
+// inset returns a PrintContext for the same page whose imageable area is reduced by
+// the specified amounts at the top and bottom.
+func (pc *PrintContext) inset(top, bottom float32) *PrintContext {
+	m := pc.margins
+	m.top += top
+	m.bottom += bottom
+	return newPrintContext(pc.pageSize, m, pc.dpi)
+}
+
+// toPixels converts a length in fyne units to pixels at the context's resolution.
+func (pc *PrintContext) toPixels(v float32) int {
+	return int(v*float32(pc.dpi)/pointsPerInch + 0.5)
+}
+
+// newPageImage creates a transparent image the size of the page.
+func (pc *PrintContext) newPageImage() *image.NRGBA {
+	return image.NewNRGBA(image.Rect(0, 0, pc.toPixels(pc.pageSize.Width),
+		pc.toPixels(pc.pageSize.Height)))
+}
+
+// renderRegion renders part of an object onto a page image.
+//
+// Params:
+//
+//	page is the page image to draw on.
+//	obj is the object to render. Its size and position are not changed.
+//	region is the size of the part of the object to render.
+//	offset is the position within the object of the top left corner of the region.
+//	at is the position on the page to draw the region at.
+func (pc *PrintContext) renderRegion(page draw.Image, obj fyne.CanvasObject,
+	region fyne.Size, offset fyne.Position, at fyne.Position) {
+	pos := obj.Position()
+	obj.Move(pos.Subtract(offset))
+	img := renderObject(container.NewWithoutLayout(obj), region, pc.dpi)
+	obj.Move(pos)
+	p := image.Pt(pc.toPixels(at.X), pc.toPixels(at.Y))
+	draw.Draw(page, img.Bounds().Add(p), img, image.Point{}, draw.Over)
+}
+
+// mmToPoints converts a length in millimeters to points.
+func mmToPoints(mm float32) float32 {
+	return mm * pointsPerInch / 25.4
+}
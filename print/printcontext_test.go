@@ -0,0 +1,23 @@
+package print
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintContext(t *testing.T) {
+	pc := newPrintContext(fyne.NewSize(612, 792), Margins{top: 18, bottom: 36, left: 18, right: 18}, 300)
+	assert.Equal(t, 300, pc.DPI())
+	assert.Equal(t, fyne.NewSize(612, 792), pc.PageSize())
+	assert.Equal(t, fyne.NewPos(18, 18), pc.ImageableOrigin())
+	assert.Equal(t, fyne.NewSize(576, 738), pc.ImageableSize())
+	img := pc.newPageImage()
+	assert.Equal(t, 2550, img.Bounds().Dx())
+	assert.Equal(t, 3300, img.Bounds().Dy())
+}
+
+func TestMMToPoints(t *testing.T) {
+	assert.InDelta(t, 72, mmToPoints(25.4), 0.001)
+}
@@ -6,10 +6,14 @@ package print
 import "C"
 import (
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"strconv"
-	"unsafe"
 
 	"fyne.io/fyne/v2"
+	"github.com/OpenPrinting/goipp"
 )
 
 // Printer represents a CUPS printer
@@ -19,6 +23,8 @@ type Printer struct {
 	dinfo      *C.cups_dinfo_t
 	caps       capabilities
 	mediaSizes MediaSizes
+	readyMedia MediaSizes
+	virtual    *virtualPrinter
 }
 
 // newPrinter creates a new Printer object.
@@ -37,20 +43,43 @@ func newPrinter(dest *C.cups_dest_t) *Printer {
 	p.http = C.cupsConnectDest(p.dest, C.CUPS_DEST_FLAGS_NONE,
 		2000, nil, nil, 0, nil, nil)
 	p.dinfo = C.cupsCopyDestInfo(p.http, p.dest)
+	p.mediaSizes = p.destMedia(0)
+	p.readyMedia = p.destMedia(C.CUPS_MEDIA_FLAGS_READY)
+	return p
+}
 
-	mCount := C.cupsGetDestMediaCount(p.http, p.dest, p.dinfo, 0)
+// destMedia retrieves the printer's media sizes that match the CUPS media flags.
+//
+// Params:
+//
+//	flags is 0 for all of the supported media sizes, or CUPS_MEDIA_FLAGS_READY for the
+//	media sizes that are loaded in the printer.
+func (p *Printer) destMedia(flags C.uint) MediaSizes {
+	var sizes MediaSizes
+	mCount := C.cupsGetDestMediaCount(p.http, p.dest, p.dinfo, flags)
 	for i := 0; i < int(mCount); i++ {
 		var mSize C.cups_size_t
 
 		res := C.cupsGetDestMediaByIndex(p.http, p.dest, p.dinfo, C.int(i),
-			0, &mSize)
+			flags, &mSize)
 		if res == 0 {
 			e := C.cupsLastErrorString()
 			fyne.LogError("Error getting media size", errors.New(C.GoString(e)))
 			continue
 		}
 		s := newMediaSize(&mSize, p)
-		p.mediaSizes.Add(s)
+		sizes.Add(s)
+	}
+	return sizes
+}
+
+// newVirtualPrinter creates a Printer for a virtual printer. Virtual printers can print
+// in color on any of the media sizes in the standard media catalog.
+func newVirtualPrinter(v *virtualPrinter) *Printer {
+	p := &Printer{virtual: v, caps: capabilities(C.CUPS_PRINTER_COLOR |
+		C.CUPS_PRINTER_SMALL | C.CUPS_PRINTER_MEDIUM | C.CUPS_PRINTER_LOCAL)}
+	for _, m := range standardMedia {
+		p.mediaSizes.Add(newStandardMediaSize(m))
 	}
 	return p
 }
@@ -67,6 +96,9 @@ func (p *Printer) Capabilities() capabilities {
 
 // Close frees any CUPS memory allocations for the Printer.
 func (p *Printer) Close() {
+	if p.virtual != nil {
+		return
+	}
 	C.httpClose(p.http)
 	C.cupsFreeDestInfo(p.dinfo)
 	p.dinfo = nil
@@ -78,12 +110,18 @@ func (p *Printer) Close() {
 // associated with the Printer.
 // This may be an empty string.
 func (p *Printer) Instance() string {
+	if p.virtual != nil {
+		return ""
+	}
 	return C.GoString(p.dest.instance)
 }
 
 // IsDefault returns whether the printer's CUPS destination object indicates that
 // this printer is the default.
 func (p *Printer) IsDefault() bool {
+	if p.virtual != nil {
+		return false
+	}
 	return !(p.dest.is_default == 0)
 }
 
@@ -108,22 +146,196 @@ func (p *Printer) MediaSizes() MediaSizes {
 	return p.mediaSizes
 }
 
+// ReadyMedia returns the media sizes that are loaded in the printer, and the trays that
+// hold them. The trays are retrieved from the printer's media-col-ready attribute.
+// Virtual printers have no loaded media.
+func (p *Printer) ReadyMedia() []ReadyMedia {
+	if len(p.readyMedia) == 0 {
+		return nil
+	}
+	var loaded []loadedMedia
+	groups, err := getResponseGroups(goipp.OpGetPrinterAttributes, p.printerURI(),
+		"media-col-ready")
+	if err == nil {
+		loaded = parseMediaColReady(groups)
+	}
+	ready := make([]ReadyMedia, len(p.readyMedia))
+	for i, m := range p.readyMedia {
+		ready[i] = ReadyMedia{MediaSize: m,
+			Source: mediaSource(loaded, m.Width(), m.Length())}
+	}
+	return ready
+}
+
 // Name retrieves the printer Name from the CUPS destination object associated
 // with the Printer.
 func (p *Printer) Name() string {
+	if p.virtual != nil {
+		return p.virtual.name
+	}
 	return C.GoString(p.dest.name)
 }
 
 // Options retrieves a map containing the options values as retrieved as
 // part of the printer's dest value.
 func (p *Printer) Options() map[string]string {
-	options := make(map[string]string)
-	oPtr := uintptr(unsafe.Pointer(p.dest.options))
-	for i := 0; i < int(p.dest.num_options); i++ {
-		// use of unsafe.Pointer on next line OK.
-		opt := (*C.cups_option_t)(unsafe.Pointer(oPtr))
-		options[C.GoString(opt.name)] = C.GoString(opt.value)
-		oPtr += uintptr(unsafe.Sizeof(opt.name)) + uintptr(unsafe.Sizeof(opt.value))
-	}
-	return options
+	if p.virtual != nil {
+		return map[string]string{}
+	}
+	return newJobOptions(p.dest.num_options, p.dest.options)
+}
+
+// printerURI returns the URI used to send IPP requests for the printer to the
+// local CUPS server.
+func (p *Printer) printerURI() string {
+	return localCupsURI + "/printers/" + url.PathEscape(p.Name())
+}
+
+// URFSupported retrieves the printer's urf-supported attribute. Printers that do not
+// accept Apple raster documents return an empty URFSupported value.
+func (p *Printer) URFSupported() (URFSupported, error) {
+	if p.virtual != nil {
+		return URFSupported{}, nil
+	}
+	groups, err := getResponseGroups(goipp.OpGetPrinterAttributes, p.printerURI(),
+		"urf-supported")
+	if err != nil {
+		return URFSupported{}, err
+	}
+	return ParseURFSupported(attributeStrings(groups, "urf-supported")), nil
+}
+
+// Status retrieves the printer's current state, state reasons and state message.
+// Virtual printers are always idle.
+func (p *Printer) Status() (PrinterStatus, error) {
+	if p.virtual != nil {
+		return PrinterStatus{State: PrinterIdle, AcceptingJobs: true}, nil
+	}
+	groups, err := getResponseGroups(goipp.OpGetPrinterAttributes, p.printerURI(),
+		"printer-state printer-state-reasons printer-state-message printer-is-accepting-jobs")
+	if err != nil {
+		return PrinterStatus{}, err
+	}
+	return parsePrinterStatus(groups), nil
+}
+
+// Supplies retrieves the printer's toner, ink and other marker supplies and their
+// levels. Virtual printers and printers that do not report their supplies have none.
+func (p *Printer) Supplies() ([]Supply, error) {
+	if p.virtual != nil {
+		return nil, nil
+	}
+	groups, err := getResponseGroups(goipp.OpGetPrinterAttributes, p.printerURI(),
+		"marker-names marker-colors marker-levels marker-low-levels marker-types")
+	if err != nil {
+		return nil, err
+	}
+	return newSupplies(attributeStrings(groups, "marker-names"),
+		attributeStrings(groups, "marker-colors"),
+		attributeStrings(groups, "marker-levels"),
+		attributeStrings(groups, "marker-low-levels"),
+		attributeStrings(groups, "marker-types")), nil
+}
+
+// DefaultScaling returns the printer's default print scaling, taken from its
+// "print-scaling" option.
+func (p *Printer) DefaultScaling() Scaling {
+	mode, err := ParsePrintScaling(p.Options()["print-scaling"])
+	if err != nil {
+		return Scaling{}
+	}
+	return Scaling{Mode: mode}
+}
+
+// PageRangesSupported returns true if the printer accepts the IPP "page-ranges" job
+// attribute.
+func (p *Printer) PageRangesSupported() bool {
+	if p.virtual != nil {
+		return false
+	}
+	groups, err := getResponseGroups(goipp.OpGetPrinterAttributes, p.printerURI(),
+		"page-ranges-supported")
+	if err != nil {
+		return false
+	}
+	values := attributeStrings(groups, "page-ranges-supported")
+	return len(values) > 0 && values[0] == "true"
+}
+
+// copySupport returns whether the printer can print multiple copies of a job and
+// whether it can collate them.
+func (p *Printer) copySupport() (bool, bool) {
+	return p.caps.CanDoCopies(), p.caps.CanCollate()
+}
+
+// canPrintColor returns true if the printer can print in color.
+func (p *Printer) canPrintColor() bool {
+	return p.caps.CanPrintColor()
+}
+
+// DefaultColorConversion returns the printer's default color conversion, taken from its
+// "print-color-mode" option. Black and white pages are dithered using Floyd-Steinberg
+// error diffusion.
+func (p *Printer) DefaultColorConversion() ColorConversion {
+	mode, err := ParseColorMode(p.Options()["print-color-mode"])
+	if err != nil {
+		return ColorConversion{Dither: DitherFloydSteinberg}
+	}
+	return ColorConversion{Mode: mode, Dither: DitherFloydSteinberg}
+}
+
+// ICCProfile downloads and parses the first color profile listed in the printer's
+// printer-icc-profiles attribute.
+func (p *Printer) ICCProfile() (*ICCProfile, error) {
+	if p.virtual != nil {
+		return nil, errors.New("the printer has no color profile")
+	}
+	groups, err := getResponseGroups(goipp.OpGetPrinterAttributes, p.printerURI(),
+		"printer-icc-profiles")
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range *groups {
+		for _, attr := range group.Attrs {
+			if attr.Name != "printer-icc-profiles" {
+				continue
+			}
+			for _, v := range attr.Values {
+				profile, ok := v.V.(goipp.Collection)
+				if !ok {
+					continue
+				}
+				for _, member := range profile {
+					if member.Name == "profile-url" && len(member.Values) > 0 {
+						return downloadICCProfile(member.Values[0].V.String())
+					}
+				}
+			}
+		}
+	}
+	return nil, errors.New("the printer has no color profile")
+}
+
+// downloadICCProfile retrieves and parses the ICC profile at a URL.
+func downloadICCProfile(profileURL string) (*ICCProfile, error) {
+	response, err := http.Get(profileURL)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error downloading color profile: %s", response.Status)
+	}
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	return ParseICCProfile(data)
+}
+
+// DefaultRenderingIntent returns the printer's default rendering intent, taken from its
+// "print-rendering-intent" option.
+func (p *Printer) DefaultRenderingIntent() RenderingIntent {
+	intent, _ := ParseRenderingIntent(p.Options()["print-rendering-intent"])
+	return intent
 }
@@ -22,6 +22,7 @@ type Printer struct {
 	mediaNames []string
 	mediaSizes []C.POINTL
 	papers     []uint16
+	virtual    *virtualPrinter
 }
 
 // newPrinter creates a Printer struct based on information provided in the PrinterInfo2 argument.
@@ -41,9 +42,65 @@ func newPrinter(pInfo2 *PrinterInfo2) *Printer {
 	return p
 }
 
+// newVirtualPrinter creates a Printer for a virtual printer. Virtual printers can print
+// in color on any of the media sizes in the standard media catalog.
+func newVirtualPrinter(v *virtualPrinter) *Printer {
+	return &Printer{virtual: v}
+}
+
+// MediaSizes returns the media sizes for the printer. Margins are not reported by the
+// printer driver, so the media sizes have no margins. Virtual printers use the sizes in
+// the standard media catalog.
+func (p *Printer) MediaSizes() MediaSizes {
+	var sizes MediaSizes
+	if p.virtual != nil {
+		for _, m := range standardMedia {
+			sizes.Add(newStandardMediaSize(m))
+		}
+		return sizes
+	}
+	for i, name := range p.mediaNames {
+		if i >= len(p.mediaSizes) || i >= len(p.papers) {
+			break
+		}
+		// paper sizes are in tenths of a millimeter
+		w, h := float32(p.mediaSizes[i].x)/10, float32(p.mediaSizes[i].y)/10
+		sizes.Add(newMediaSizeWithData(name, paperSize(p.papers[i]), w, h, w, h, 0, 0))
+	}
+	return sizes
+}
+
+// ReadyMedia returns the media sizes that are loaded in the printer. Windows printer
+// drivers do not report loaded media, so this is the paper size that the printer is
+// set up for, in its default source. Virtual printers have no loaded media.
+func (p *Printer) ReadyMedia() []ReadyMedia {
+	if p.virtual != nil {
+		return nil
+	}
+	dm := p.devModeSettings()
+	for _, m := range p.MediaSizes() {
+		if m.PaperSize() == dm.PaperSize() {
+			return []ReadyMedia{{MediaSize: m, Source: dm.DefaultSource().mediaSource()}}
+		}
+	}
+	return nil
+}
+
+// Name returns the printer's name.
+func (p *Printer) Name() string {
+	if p.virtual != nil {
+		return p.virtual.name
+	}
+	return p.pi2.PrinterName()
+}
+
 // String returns a string representation of the Printer struct.
 func (pr *Printer) String() string {
 	var s strings.Builder
+	if pr.virtual != nil {
+		s.WriteString(fmt.Sprintf("    Virtual Printer: %s\n", pr.virtual.name))
+		return s.String()
+	}
 	s.WriteString(fmt.Sprintf("    Handle: %d\n", pr.handle))
 	s.WriteString(fmt.Sprintf("    DC: %d\n", pr.dc))
 	s.WriteString(prepend("    ", pr.pi2.String()))
@@ -210,6 +267,9 @@ func (p *Printer) getPapers() error {
 
 // close cleans up Printer-related data such as the printer handle.
 func (p *Printer) close() {
+	if p.virtual != nil {
+		return
+	}
 	// release the printer's device context (dc)
 	if p.dc != 0 {
 		deleteDC(p.dc)
@@ -219,3 +279,144 @@ func (p *Printer) close() {
 	closePrinter(p.handle)
 	p.handle = 0
 }
+
+// Status retrieves the printer's current state and the reasons for it from the spooler.
+// Virtual printers are always idle.
+func (p *Printer) Status() (PrinterStatus, error) {
+	if p.virtual != nil {
+		return PrinterStatus{State: PrinterIdle, AcceptingJobs: true}, nil
+	}
+	var needed uint32
+	_, err := getPrinter(p.handle, 2, nil, 0, &needed)
+	if err != syscall.ERROR_INSUFFICIENT_BUFFER {
+		return PrinterStatus{}, err
+	}
+	buffer := make([]byte, needed)
+	ok, err := getPrinter(p.handle, 2, &buffer[0], needed, &needed)
+	if !ok {
+		return PrinterStatus{}, err
+	}
+	pi2 := (*PrinterInfo2)(unsafe.Pointer(&buffer[0]))
+	return pi2.PrinterStatus().status(), nil
+}
+
+// Supplies returns the printer's supplies. The Windows spooler does not report supply
+// levels, so there are none.
+func (p *Printer) Supplies() ([]Supply, error) {
+	return nil, nil
+}
+
+// Validate checks whether the printer would accept a job with the options and document
+// format. Windows printers only accept raw documents, and job options are ignored.
+// Virtual printers accept every job.
+//
+// Params:
+//
+//	options are the job options that the job will be submitted with.
+//	format is the MIME type of the document.
+//
+// Returns the options that the printer does not accept, or nil if the job is valid.
+func (p *Printer) Validate(options JobOptions, format string) (Conflicts, error) {
+	if p.virtual != nil || format == RawFormat {
+		return nil, nil
+	}
+	return Conflicts{newConflict("document-format", format)}, nil
+}
+
+// ResolveConflicts finds the job options that conflict with a changed option. Job
+// options are ignored on Windows, so there are never any conflicts.
+//
+// Params:
+//
+//	options are the current job options.
+//	option and value are the option that is changed and its new value.
+func (p *Printer) ResolveConflicts(options JobOptions, option, value string) (
+	OptionConflicts, error) {
+	return OptionConflicts{}, nil
+}
+
+// devModeSettings returns the printer's devMode so that its settings can be read. The
+// pDevMode member of PRINTER_INFO_2 may be NULL, in which case a zero devMode, with no
+// fields set, is returned.
+func (p *Printer) devModeSettings() *devMode {
+	if dm := p.pi2.DevMode(); dm != nil {
+		return dm
+	}
+	return &devMode{}
+}
+
+// DefaultScaling returns the printer's default print scaling. A scale other than 100
+// percent in the printer's devMode prints pages at that scale.
+func (p *Printer) DefaultScaling() Scaling {
+	if p.virtual != nil {
+		return Scaling{}
+	}
+	scale := p.devModeSettings().Scale()
+	if scale == 0 || scale == 100 {
+		return Scaling{}
+	}
+	return Scaling{Mode: ScalingNone, Percent: float32(scale)}
+}
+
+// PageRangesSupported returns false because job options are not passed to Windows
+// printers. Page ranges are always applied before the document is submitted.
+func (p *Printer) PageRangesSupported() bool {
+	return false
+}
+
+// copySupport returns false for both copies and collation because job options are not
+// passed to Windows printers. Copies are always generated before the document is
+// submitted.
+func (p *Printer) copySupport() (bool, bool) {
+	return false, false
+}
+
+// canPrintColor returns true if the printer driver reports that it is a color device.
+func (p *Printer) canPrintColor() bool {
+	if p.virtual != nil {
+		return true
+	}
+	n, _ := deviceCapabilities(p.pi2.PrinterName(), p.pi2.PortName(), dcColorDevice, 0,
+		p.pi2.DevMode())
+	return n == 1
+}
+
+// DefaultColorConversion returns the printer's default color conversion, taken from the
+// color and dither type settings in its devMode. A monochrome devMode prints black and
+// white pages using the dither type, unless the dither type is not set or the device
+// does the grayscaling, in which case the pages are printed in grayscale.
+func (p *Printer) DefaultColorConversion() ColorConversion {
+	if p.virtual != nil {
+		return ColorConversion{}
+	}
+	dm := p.devModeSettings()
+	if dm.Color() != C.DMCOLOR_MONOCHROME {
+		return ColorConversion{}
+	}
+	dither, ok := dm.DitherType().dither()
+	if !dm.Fields().ditherTypeSet() || !ok {
+		return ColorConversion{Mode: ColorModeMonochrome}
+	}
+	return ColorConversion{Mode: ColorModeBiLevel, Dither: dither}
+}
+
+// ICCProfile loads the color profile that is associated with the printer.
+func (p *Printer) ICCProfile() (*ICCProfile, error) {
+	if p.virtual != nil {
+		return nil, errors.New("the printer has no color profile")
+	}
+	name, err := getICMProfile(p.dc)
+	if err != nil {
+		return nil, err
+	}
+	return LoadICCProfile(name)
+}
+
+// DefaultRenderingIntent returns the rendering intent set by the ICM intent in the
+// printer's devMode.
+func (p *Printer) DefaultRenderingIntent() RenderingIntent {
+	if p.virtual != nil {
+		return IntentPerceptual
+	}
+	return p.devModeSettings().ICMIntent().renderingIntent()
+}
@@ -259,6 +259,51 @@ func (a attributes) String() string {
 
 type printerStatus uint32
 
+// printerStatusReasons maps printer status flags to IPP printer-state-reasons keywords.
+var printerStatusReasons = []struct {
+	flag   printerStatus
+	reason string
+}{
+	{C.PRINTER_STATUS_PAUSED, ReasonPaused},
+	{C.PRINTER_STATUS_PAPER_JAM, ReasonMediaJam},
+	{C.PRINTER_STATUS_PAPER_OUT, ReasonMediaEmpty},
+	{C.PRINTER_STATUS_PAPER_PROBLEM, ReasonMediaNeeded},
+	{C.PRINTER_STATUS_MANUAL_FEED, ReasonMediaNeeded},
+	{C.PRINTER_STATUS_OFFLINE, ReasonOffline},
+	{C.PRINTER_STATUS_NOT_AVAILABLE, ReasonOffline},
+	{C.PRINTER_STATUS_OUTPUT_BIN_FULL, "output-area-full"},
+	{C.PRINTER_STATUS_TONER_LOW, ReasonTonerLow},
+	{C.PRINTER_STATUS_NO_TONER, ReasonTonerEmpty},
+	{C.PRINTER_STATUS_DOOR_OPEN, ReasonDoorOpen},
+	{C.PRINTER_STATUS_OUT_OF_MEMORY, "other"},
+	{C.PRINTER_STATUS_USER_INTERVENTION, "other"},
+}
+
+// status converts the printer status flags to a PrinterStatus.
+func (ps printerStatus) status() PrinterStatus {
+	status := PrinterStatus{State: PrinterIdle, AcceptingJobs: true}
+	for _, r := range printerStatusReasons {
+		if ps&r.flag != 0 {
+			status.Reasons = append(status.Reasons, r.reason)
+		}
+	}
+	switch {
+	case ps&(C.PRINTER_STATUS_PAUSED|C.PRINTER_STATUS_ERROR|C.PRINTER_STATUS_OFFLINE|
+		C.PRINTER_STATUS_NOT_AVAILABLE|C.PRINTER_STATUS_PENDING_DELETION) != 0:
+		status.State = PrinterStopped
+	case ps&(C.PRINTER_STATUS_PRINTING|C.PRINTER_STATUS_PROCESSING|
+		C.PRINTER_STATUS_BUSY|C.PRINTER_STATUS_IO_ACTIVE) != 0:
+		status.State = PrinterProcessing
+	}
+	if ps&C.PRINTER_STATUS_PENDING_DELETION != 0 {
+		status.AcceptingJobs = false
+	}
+	if ps != 0 {
+		status.Message = strings.Join(strings.Split(strings.TrimSpace(ps.String()), "    "), ", ")
+	}
+	return status
+}
+
 // String outputs the printer status as a string.
 // PrinterStatus returns the printer status as a string slice.
 // The following statuses are not defined by cgo, so they cannot
@@ -0,0 +1,218 @@
+package print
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The job options that PrinterOptions reports for every printer.
+const (
+	OptionSides       = "sides"
+	OptionColorMode   = "print-color-mode"
+	OptionQuality     = "print-quality"
+	OptionResolution  = "printer-resolution"
+	OptionFinishings  = "finishings"
+	OptionOutputBin   = "output-bin"
+	OptionMediaSource = "media-source"
+	OptionMediaType   = "media-type"
+)
+
+// standardOptions are the job options that PrinterOptions reports for every printer.
+var standardOptions = []string{OptionSides, OptionColorMode, OptionQuality,
+	OptionResolution, OptionFinishings, OptionOutputBin, OptionMediaSource,
+	OptionMediaType}
+
+// OptionValues are the values of a job option that a printer supports.
+type OptionValues struct {
+	// Supported are the values that the printer supports. It is empty if the printer
+	// does not support the option.
+	Supported []string
+	// Default is the value that the printer uses when a job does not specify one.
+	Default string
+	// Ready are the supported values that can be used without user intervention, such
+	// as media types that are loaded in the printer. It is empty if the printer does
+	// not report them.
+	Ready []string
+}
+
+// IsSupported returns true if the printer supports the value.
+func (v OptionValues) IsSupported(value string) bool {
+	for _, s := range v.Supported {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReady returns true if the value can be used without user intervention. Values are
+// ready if they are supported and the printer does not report ready values.
+func (v OptionValues) IsReady(value string) bool {
+	if len(v.Ready) == 0 {
+		return v.IsSupported(value)
+	}
+	for _, r := range v.Ready {
+		if r == value {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintQuality is the IPP "print-quality" enum.
+type PrintQuality int
+
+const (
+	QualityDraft  PrintQuality = 3
+	QualityNormal PrintQuality = 4
+	QualityHigh   PrintQuality = 5
+)
+
+// String returns the IPP keyword for the print quality.
+func (q PrintQuality) String() string {
+	switch q {
+	case QualityDraft:
+		return "draft"
+	case QualityHigh:
+		return "high"
+	}
+	return "normal"
+}
+
+// ParsePrintQuality converts a print-quality keyword, such as "draft", or enum value,
+// such as "3", to a PrintQuality.
+func ParsePrintQuality(value string) (PrintQuality, error) {
+	for _, q := range []PrintQuality{QualityDraft, QualityNormal, QualityHigh} {
+		if value == q.String() || value == strconv.Itoa(int(q)) {
+			return q, nil
+		}
+	}
+	return QualityNormal, fmt.Errorf("unknown print-quality value: %s", value)
+}
+
+// Resolution is a printer resolution in dots per inch.
+type Resolution struct {
+	X, Y int
+}
+
+// String returns the resolution in the form used by CUPS, for example "600dpi" or
+// "600x1200dpi".
+func (r Resolution) String() string {
+	if r.X == r.Y {
+		return fmt.Sprintf("%ddpi", r.X)
+	}
+	return fmt.Sprintf("%dx%ddpi", r.X, r.Y)
+}
+
+// ParseResolution converts a resolution such as "600dpi", "600x1200dpi" or "236dpcm"
+// to a Resolution in dots per inch.
+func ParseResolution(value string) (Resolution, error) {
+	scale := 1.0
+	text := value
+	switch {
+	case strings.HasSuffix(text, "dpi"):
+		text = strings.TrimSuffix(text, "dpi")
+	case strings.HasSuffix(text, "dpcm"):
+		text = strings.TrimSuffix(text, "dpcm")
+		scale = 2.54
+	default:
+		return Resolution{}, fmt.Errorf("unknown printer-resolution value: %s", value)
+	}
+	xText, yText, found := strings.Cut(text, "x")
+	if !found {
+		yText = xText
+	}
+	x, err := strconv.Atoi(xText)
+	y, err2 := strconv.Atoi(yText)
+	if err != nil || err2 != nil {
+		return Resolution{}, fmt.Errorf("unknown printer-resolution value: %s", value)
+	}
+	return Resolution{X: int(float64(x)*scale + 0.5), Y: int(float64(y)*scale + 0.5)}, nil
+}
+
+// PrinterOptions are the supported values and defaults of a printer's job options,
+// keyed by the IPP option name.
+type PrinterOptions map[string]OptionValues
+
+// Sides returns the supported values of the "sides" option, such as
+// "two-sided-long-edge".
+func (o PrinterOptions) Sides() OptionValues {
+	return o[OptionSides]
+}
+
+// ColorModes returns the supported color modes and the default color mode. Color
+// modes that the print package cannot produce are ignored.
+func (o PrinterOptions) ColorModes() ([]ColorMode, ColorMode) {
+	values := o[OptionColorMode]
+	var modes []ColorMode
+	for _, v := range values.Supported {
+		if mode, err := ParseColorMode(v); err == nil && v != "auto" {
+			modes = append(modes, mode)
+		}
+	}
+	mode, _ := ParseColorMode(values.Default)
+	return modes, mode
+}
+
+// Qualities returns the supported print qualities and the default print quality.
+func (o PrinterOptions) Qualities() ([]PrintQuality, PrintQuality) {
+	values := o[OptionQuality]
+	var qualities []PrintQuality
+	for _, v := range values.Supported {
+		if q, err := ParsePrintQuality(v); err == nil {
+			qualities = append(qualities, q)
+		}
+	}
+	quality, _ := ParsePrintQuality(values.Default)
+	return qualities, quality
+}
+
+// Resolutions returns the supported printer resolutions and the default resolution.
+// The default is the zero Resolution if the printer does not report one.
+func (o PrinterOptions) Resolutions() ([]Resolution, Resolution) {
+	values := o[OptionResolution]
+	var resolutions []Resolution
+	for _, v := range values.Supported {
+		if r, err := ParseResolution(v); err == nil {
+			resolutions = append(resolutions, r)
+		}
+	}
+	resolution, _ := ParseResolution(values.Default)
+	return resolutions, resolution
+}
+
+// Finishings returns the supported values of the "finishings" option, such as
+// "staple".
+func (o PrinterOptions) Finishings() OptionValues {
+	return o[OptionFinishings]
+}
+
+// OutputBins returns the supported values of the "output-bin" option.
+func (o PrinterOptions) OutputBins() OptionValues {
+	return o[OptionOutputBin]
+}
+
+// MediaSources returns the supported values of the "media-source" option, such as
+// "tray-1".
+func (o PrinterOptions) MediaSources() OptionValues {
+	return o[OptionMediaSource]
+}
+
+// MediaTypes returns the supported values of the "media-type" option, such as
+// "stationery".
+func (o PrinterOptions) MediaTypes() OptionValues {
+	return o[OptionMediaType]
+}
+
+// SupportedOptions returns the supported values and defaults of the sides,
+// print-color-mode, print-quality, printer-resolution, finishings, output-bin,
+// media-source and media-type job options. Options that the printer does not support
+// have no supported values.
+func (p *Printer) SupportedOptions() PrinterOptions {
+	options := PrinterOptions{}
+	for _, option := range standardOptions {
+		options[option] = p.JobOptionValues(option)
+	}
+	return options
+}
@@ -0,0 +1,66 @@
+package print
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseResolution(t *testing.T) {
+	r, err := ParseResolution("600dpi")
+	assert.Nil(t, err)
+	assert.Equal(t, Resolution{X: 600, Y: 600}, r)
+	r, err = ParseResolution("600x1200dpi")
+	assert.Nil(t, err)
+	assert.Equal(t, Resolution{X: 600, Y: 1200}, r)
+	assert.Equal(t, "600x1200dpi", r.String())
+	r, err = ParseResolution("118dpcm")
+	assert.Nil(t, err)
+	assert.Equal(t, "300dpi", r.String())
+	_, err = ParseResolution("fine")
+	assert.NotNil(t, err)
+}
+
+func TestParsePrintQuality(t *testing.T) {
+	q, err := ParsePrintQuality("draft")
+	assert.Nil(t, err)
+	assert.Equal(t, QualityDraft, q)
+	q, err = ParsePrintQuality("5")
+	assert.Nil(t, err)
+	assert.Equal(t, QualityHigh, q)
+	_, err = ParsePrintQuality("best")
+	assert.NotNil(t, err)
+}
+
+func TestPrinterOptions(t *testing.T) {
+	options := PrinterOptions{
+		OptionSides: {Supported: []string{"one-sided", "two-sided-long-edge"},
+			Default: "one-sided"},
+		OptionColorMode: {Supported: []string{"auto", "color", "monochrome"},
+			Default: "monochrome"},
+		OptionQuality: {Supported: []string{"draft", "normal"}, Default: "normal"},
+		OptionResolution: {Supported: []string{"300dpi", "600dpi"},
+			Default: "600dpi"},
+		OptionMediaType: {Supported: []string{"stationery", "transparency"},
+			Ready: []string{"stationery"}},
+	}
+	assert.True(t, options.Sides().IsSupported("two-sided-long-edge"))
+	assert.False(t, options.Sides().IsSupported("two-sided-short-edge"))
+
+	modes, mode := options.ColorModes()
+	assert.Equal(t, []ColorMode{ColorModeColor, ColorModeMonochrome}, modes)
+	assert.Equal(t, ColorModeMonochrome, mode)
+
+	qualities, quality := options.Qualities()
+	assert.Equal(t, []PrintQuality{QualityDraft, QualityNormal}, qualities)
+	assert.Equal(t, QualityNormal, quality)
+
+	resolutions, resolution := options.Resolutions()
+	assert.Equal(t, 2, len(resolutions))
+	assert.Equal(t, Resolution{X: 600, Y: 600}, resolution)
+
+	assert.True(t, options.MediaTypes().IsReady("stationery"))
+	assert.False(t, options.MediaTypes().IsReady("transparency"))
+	assert.True(t, options.Sides().IsReady("one-sided"))
+	assert.Empty(t, options.Finishings().Supported)
+}
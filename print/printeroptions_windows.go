@@ -0,0 +1,106 @@
+package print
+
+//#define UNICODE
+//#include "windows.h"
+import "C"
+import (
+	"syscall"
+	"unsafe"
+)
+
+// JobOptionValues returns the values that the printer supports for a job option, as
+// reported by its driver. The defaults are taken from the printer's devMode. Only the
+// options reported by SupportedOptions are known.
+//
+// Params:
+//
+//	option is the IPP name of the job option, for example OptionSides.
+func (p *Printer) JobOptionValues(option string) OptionValues {
+	if p.virtual != nil {
+		return virtualOptions[option]
+	}
+	dm := p.devModeSettings()
+	switch option {
+	case OptionSides:
+		values := OptionValues{Supported: []string{"one-sided"}, Default: "one-sided"}
+		if n, _ := deviceCapabilities(p.pi2.PrinterName(), p.pi2.PortName(), dcDuplex, 0,
+			p.pi2.DevMode()); n == 1 {
+			values.Supported = append(values.Supported, "two-sided-long-edge",
+				"two-sided-short-edge")
+		}
+		switch dm.Duplex() {
+		case C.DMDUP_VERTICAL:
+			values.Default = "two-sided-long-edge"
+		case C.DMDUP_HORIZONTAL:
+			values.Default = "two-sided-short-edge"
+		}
+		return values
+	case OptionColorMode:
+		values := OptionValues{Supported: []string{"monochrome"}, Default: "monochrome"}
+		if p.canPrintColor() {
+			values.Supported = []string{"color", "monochrome"}
+			if dm.Color() == C.DMCOLOR_COLOR {
+				values.Default = "color"
+			}
+		}
+		return values
+	case OptionQuality:
+		values := OptionValues{Supported: []string{"draft", "normal", "high"}}
+		switch dm.PrintQuality() {
+		case C.DMRES_DRAFT, C.DMRES_LOW:
+			values.Default = "draft"
+		case C.DMRES_MEDIUM:
+			values.Default = "normal"
+		case C.DMRES_HIGH:
+			values.Default = "high"
+		}
+		return values
+	case OptionResolution:
+		var values OptionValues
+		for _, r := range p.resolutions() {
+			values.Supported = append(values.Supported, r.String())
+		}
+		if q := dm.PrintQuality(); q > 0 {
+			values.Default = Resolution{X: int(q), Y: int(dm.YResolution())}.String()
+		}
+		return values
+	case OptionMediaSource:
+		return OptionValues{Supported: p.binNames()}
+	}
+	return OptionValues{}
+}
+
+// resolutions returns the resolutions that the printer driver supports.
+func (p *Printer) resolutions() []Resolution {
+	num, _ := deviceCapabilities(p.pi2.PrinterName(), p.pi2.PortName(), dcEnumResolutions,
+		0, p.pi2.DevMode())
+	if num <= 0 {
+		return nil
+	}
+	// each resolution is a pair of LONG values, x then y
+	dpi := make([]int32, 2*num)
+	num, _ = deviceCapabilities(p.pi2.PrinterName(), p.pi2.PortName(), dcEnumResolutions,
+		uintptr(unsafe.Pointer(&dpi[0])), p.pi2.DevMode())
+	var resolutions []Resolution
+	for i := 0; i < int(num) && 2*i+1 < len(dpi); i++ {
+		resolutions = append(resolutions, Resolution{X: int(dpi[2*i]), Y: int(dpi[2*i+1])})
+	}
+	return resolutions
+}
+
+// binNames returns the names of the printer's paper bins.
+func (p *Printer) binNames() []string {
+	num, _ := deviceCapabilities(p.pi2.PrinterName(), p.pi2.PortName(), dcBinNames, 0,
+		p.pi2.DevMode())
+	if num <= 0 {
+		return nil
+	}
+	names := make([][24]uint16, num)
+	num, _ = deviceCapabilities(p.pi2.PrinterName(), p.pi2.PortName(), dcBinNames,
+		uintptr(unsafe.Pointer(&names[0][0])), p.pi2.DevMode())
+	var bins []string
+	for i := 0; i < int(num) && i < len(names); i++ {
+		bins = append(bins, syscall.UTF16ToString(names[i][:]))
+	}
+	return bins
+}
@@ -13,7 +13,8 @@ type Printers struct {
 	Printers []Printer
 }
 
-// NewPrinters generates Printer objects for each CUPS printer.
+// NewPrinters generates Printer objects for each CUPS printer, followed by the virtual
+// PDF printer.
 func NewPrinters() *Printers {
 	ps := &Printers{}
 	var dests *C.cups_dest_t
@@ -25,9 +26,15 @@ func NewPrinters() *Printers {
 		pr := newPrinter(d)
 		ps.Printers = append(ps.Printers, *pr)
 	}
+	ps.Printers = append(ps.Printers, *NewPDFPrinter(nil))
 	return ps
 }
 
+// Add adds a printer, such as a virtual printer, to the Printers struct.
+func (p *Printers) Add(printer *Printer) {
+	p.Printers = append(p.Printers, *printer)
+}
+
 // Close frees CUPS memory assigned to each Printer.
 func (p *Printers) Close() {
 	for _, pr := range p.Printers {
@@ -15,6 +15,8 @@ import (
 // printers available on the computer.
 type Printers []Printer
 
+// NewPrinters returns the printers that are available on the computer, followed by the
+// virtual PDF printer.
 func NewPrinters() *Printers {
 	var flags uint32 = C.PRINTER_ENUM_LOCAL |
 		C.PRINTER_ENUM_CONNECTIONS
@@ -58,6 +60,7 @@ func NewPrinters() *Printers {
 		p := newPrinter(&info2)
 		printers.Add(p)
 	}
+	printers.Add(NewPDFPrinter(nil))
 	return printers
 }
 
@@ -0,0 +1,244 @@
+package print
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// PrinterState is the state of a printer. The values match the IPP "printer-state"
+// enum.
+type PrinterState int
+
+const (
+	PrinterStateUnknown PrinterState = 0
+	PrinterIdle         PrinterState = 3 // the printer is ready to print
+	PrinterProcessing   PrinterState = 4 // the printer is printing a job
+	PrinterStopped      PrinterState = 5 // the printer has stopped, see the state reasons
+)
+
+// String returns the IPP keyword for the printer state.
+func (s PrinterState) String() string {
+	switch s {
+	case PrinterIdle:
+		return "idle"
+	case PrinterProcessing:
+		return "processing"
+	case PrinterStopped:
+		return "stopped"
+	}
+	return "unknown"
+}
+
+// Printer state reasons that applications commonly react to. The values are IPP
+// "printer-state-reasons" keywords without their severity suffix.
+const (
+	ReasonNone        = "none"
+	ReasonMediaJam    = "media-jam"
+	ReasonMediaLow    = "media-low"
+	ReasonMediaEmpty  = "media-empty"
+	ReasonMediaNeeded = "media-needed"
+	ReasonTonerLow    = "toner-low"
+	ReasonTonerEmpty  = "toner-empty"
+	ReasonDoorOpen    = "door-open"
+	ReasonOffline     = "offline"
+	ReasonPaused      = "paused"
+)
+
+// reasonSeverities are the suffixes that printers may append to state reasons.
+var reasonSeverities = []string{"-report", "-warning", "-error"}
+
+// PrinterStatus is a snapshot of the state of a printer.
+type PrinterStatus struct {
+	State PrinterState
+	// Reasons are the printer-state-reasons keywords, such as "media-empty-error".
+	// A printer without problems has no reasons.
+	Reasons []string
+	// Message is the printer-state-message, a human readable description of the
+	// state.
+	Message       string
+	AcceptingJobs bool
+}
+
+// HasReason returns true if the status contains the state reason, with or without a
+// severity suffix.
+//
+// Params:
+//
+//	reason is the state reason without a severity suffix, for example ReasonMediaEmpty.
+func (s PrinterStatus) HasReason(reason string) bool {
+	for _, r := range s.Reasons {
+		if trimSeverity(r) == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOffline returns true if the printer cannot be reached.
+func (s PrinterStatus) IsOffline() bool {
+	return s.HasReason(ReasonOffline)
+}
+
+// IsOutOfPaper returns true if the printer needs media to be loaded.
+func (s PrinterStatus) IsOutOfPaper() bool {
+	return s.HasReason(ReasonMediaEmpty) || s.HasReason(ReasonMediaNeeded)
+}
+
+// Equal returns true if the two statuses have the same state, reasons and message.
+func (s PrinterStatus) Equal(other PrinterStatus) bool {
+	return s.State == other.State && s.Message == other.Message &&
+		s.AcceptingJobs == other.AcceptingJobs &&
+		strings.Join(s.Reasons, " ") == strings.Join(other.Reasons, " ")
+}
+
+// trimSeverity removes the severity suffix from a state reason.
+func trimSeverity(reason string) string {
+	for _, severity := range reasonSeverities {
+		if strings.HasSuffix(reason, severity) {
+			return strings.TrimSuffix(reason, severity)
+		}
+	}
+	return reason
+}
+
+// newStateReasons returns the state reasons of a printer-state-reasons value, dropping
+// "none".
+func newStateReasons(values []string) []string {
+	var reasons []string
+	for _, v := range values {
+		if v != "" && v != ReasonNone {
+			reasons = append(reasons, v)
+		}
+	}
+	return reasons
+}
+
+// StatusListener is called when the status of a monitored printer changes.
+//
+// Params:
+//
+//	previous is the status before the change. Its State is PrinterStateUnknown for the
+//	first status that is retrieved.
+//	current is the new status.
+type StatusListener func(previous, current PrinterStatus)
+
+// StatusMonitor polls a printer's status and notifies listeners when it changes.
+// Listeners are called on the monitor's goroutine.
+type StatusMonitor struct {
+	interval  time.Duration
+	poll      func() (PrinterStatus, error)
+	mu        sync.Mutex
+	listeners []StatusListener
+	last      PrinterStatus
+	stop      chan struct{}
+}
+
+// defaultStatusInterval is the time between polls of a StatusMonitor that is created
+// without a valid interval.
+const defaultStatusInterval = 5 * time.Second
+
+// NewStatusMonitor creates a monitor for the status of a printer. Call Start to begin
+// polling.
+//
+// Params:
+//
+//	printer is the printer to monitor.
+//	interval is the time between polls. Intervals that are not positive poll every five
+//	seconds.
+func NewStatusMonitor(printer *Printer, interval time.Duration) *StatusMonitor {
+	if interval <= 0 {
+		interval = defaultStatusInterval
+	}
+	return &StatusMonitor{interval: interval, poll: printer.Status}
+}
+
+// Subscribe adds a listener that is called each time the printer status changes.
+func (m *StatusMonitor) Subscribe(listener StatusListener) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, listener)
+}
+
+// OnOffline adds a listener that is called when the printer goes offline.
+func (m *StatusMonitor) OnOffline(f func(status PrinterStatus)) {
+	m.Subscribe(func(previous, current PrinterStatus) {
+		if current.IsOffline() && !previous.IsOffline() {
+			f(current)
+		}
+	})
+}
+
+// OnOutOfPaper adds a listener that is called when the printer runs out of paper.
+func (m *StatusMonitor) OnOutOfPaper(f func(status PrinterStatus)) {
+	m.Subscribe(func(previous, current PrinterStatus) {
+		if current.IsOutOfPaper() && !previous.IsOutOfPaper() {
+			f(current)
+		}
+	})
+}
+
+// Status returns the most recently retrieved printer status.
+func (m *StatusMonitor) Status() PrinterStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.last
+}
+
+// Start begins polling the printer status. Calling Start on a running monitor does
+// nothing.
+func (m *StatusMonitor) Start() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stop != nil {
+		return
+	}
+	m.stop = make(chan struct{})
+	go m.run(m.stop)
+}
+
+// Stop stops polling the printer status.
+func (m *StatusMonitor) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stop != nil {
+		close(m.stop)
+		m.stop = nil
+	}
+}
+
+// run polls the printer until stop is closed.
+func (m *StatusMonitor) run(stop chan struct{}) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		m.update()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// update retrieves the printer status and notifies the listeners if it has changed.
+// Printers that cannot be queried are reported as offline.
+func (m *StatusMonitor) update() {
+	status, err := m.poll()
+	if err != nil {
+		status = PrinterStatus{State: PrinterStopped, Reasons: []string{ReasonOffline},
+			Message: err.Error()}
+	}
+	m.mu.Lock()
+	previous := m.last
+	if previous.Equal(status) {
+		m.mu.Unlock()
+		return
+	}
+	m.last = status
+	listeners := append([]StatusListener{}, m.listeners...)
+	m.mu.Unlock()
+	for _, listener := range listeners {
+		listener(previous, status)
+	}
+}
@@ -0,0 +1,70 @@
+package print
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrinterStatus_Reasons(t *testing.T) {
+	status := PrinterStatus{State: PrinterStopped,
+		Reasons: []string{"media-empty-error", "toner-low-warning", "door-open"}}
+	assert.True(t, status.HasReason(ReasonMediaEmpty))
+	assert.True(t, status.HasReason(ReasonTonerLow))
+	assert.True(t, status.HasReason(ReasonDoorOpen))
+	assert.False(t, status.HasReason(ReasonMediaJam))
+	assert.True(t, status.IsOutOfPaper())
+	assert.False(t, status.IsOffline())
+	assert.True(t, PrinterStatus{Reasons: []string{"offline-report"}}.IsOffline())
+	assert.Equal(t, "stopped", status.State.String())
+	assert.Equal(t, "unknown", PrinterStateUnknown.String())
+}
+
+func TestNewStateReasons(t *testing.T) {
+	assert.Nil(t, newStateReasons([]string{"none"}))
+	assert.Equal(t, []string{"paused"}, newStateReasons([]string{"paused"}))
+}
+
+func TestStatusMonitor_Update(t *testing.T) {
+	statuses := []PrinterStatus{
+		{State: PrinterIdle, AcceptingJobs: true},
+		{State: PrinterIdle, AcceptingJobs: true},
+		{State: PrinterStopped, Reasons: []string{"media-empty-error"}},
+	}
+	var pollErr error
+	m := &StatusMonitor{poll: func() (PrinterStatus, error) {
+		if pollErr != nil {
+			return PrinterStatus{}, pollErr
+		}
+		status := statuses[0]
+		statuses = statuses[1:]
+		return status, nil
+	}}
+	changes, outOfPaper, offline := 0, 0, 0
+	m.Subscribe(func(previous, current PrinterStatus) { changes++ })
+	m.OnOutOfPaper(func(status PrinterStatus) { outOfPaper++ })
+	m.OnOffline(func(status PrinterStatus) { offline++ })
+
+	m.update()
+	m.update()
+	assert.Equal(t, 1, changes)
+	assert.Equal(t, PrinterIdle, m.Status().State)
+	m.update()
+	assert.Equal(t, 2, changes)
+	assert.Equal(t, 1, outOfPaper)
+
+	pollErr = errors.New("connection refused")
+	m.update()
+	m.update()
+	assert.Equal(t, 3, changes)
+	assert.Equal(t, 1, offline)
+	assert.Equal(t, "connection refused", m.Status().Message)
+}
+
+func TestNewStatusMonitor_Interval(t *testing.T) {
+	assert.Equal(t, defaultStatusInterval, NewStatusMonitor(&Printer{}, 0).interval)
+	assert.Equal(t, defaultStatusInterval, NewStatusMonitor(&Printer{}, -time.Second).interval)
+	assert.Equal(t, time.Second, NewStatusMonitor(&Printer{}, time.Second).interval)
+}
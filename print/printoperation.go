@@ -1,6 +1,13 @@
 package print
 
 import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"strings"
+	"time"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/dialog"
 )
@@ -10,6 +17,20 @@ var PrintOP *PrintOperation
 // PrintOperation is the object that controls fyne print operations.
 type PrintOperation struct {
 	pageSetupDialog *dialog.ConfirmDialog
+	pageSetupInfo   *PageSetupInfo
+	document        *Document
+	header          *HeaderFooter
+	footer          *HeaderFooter
+	firstHeader     *HeaderFooter
+	firstFooter     *HeaderFooter
+	watermarks      []*Watermark
+	pageRanges      PageRanges
+	copies          Copies
+	manualDuplex    *ManualDuplex
+	colorConversion ColorConversion
+	colorManagement ColorManagement
+	options         JobOptions
+	window          fyne.Window
 }
 
 // NewPrintOperation creates a new PrintOperation object.
@@ -18,8 +39,8 @@ type PrintOperation struct {
 //
 //	window is the window that will contain the menu items for page setup and print.
 func NewPrintOperation(window fyne.Window) *PrintOperation {
-	printOp := &PrintOperation{}
-	printOp.pageSetupDialog = NewPageSetupDialog(window, nil)
+	printOp := &PrintOperation{pageSetupInfo: &PageSetupInfo{}, window: window}
+	printOp.pageSetupDialog = NewPageSetupDialog(window, printOp.pageSetupInfo)
 
 	return printOp
 }
@@ -27,3 +48,444 @@ func NewPrintOperation(window fyne.Window) *PrintOperation {
 func (po *PrintOperation) PageSetupDialog() *dialog.ConfirmDialog {
 	return po.pageSetupDialog
 }
+
+// PageSetupInfo returns the page setup settings used by the print operation. These are
+// updated when the page setup dialog is confirmed.
+func (po *PrintOperation) PageSetupInfo() *PageSetupInfo {
+	return po.pageSetupInfo
+}
+
+// SetPrinter sets the printer that the job is formatted for. The page setup dialog
+// lists its media sizes.
+//
+// Params:
+//
+//	printer is the printer that the job is formatted for.
+func (po *PrintOperation) SetPrinter(printer *Printer) {
+	if po.pageSetupInfo == nil {
+		po.pageSetupInfo = &PageSetupInfo{}
+	}
+	po.pageSetupInfo.SetPrinter(printer)
+}
+
+// SetPrintContext sets the print context for the media that the job is printed on. The
+// page setup dialog previews the scaling on it.
+//
+// Params:
+//
+//	pc is the print context that describes the page.
+func (po *PrintOperation) SetPrintContext(pc *PrintContext) {
+	if po.pageSetupInfo == nil {
+		po.pageSetupInfo = &PageSetupInfo{}
+	}
+	po.pageSetupInfo.SetPrintContext(pc)
+}
+
+// Document returns the document that the print operation prints.
+func (po *PrintOperation) Document() *Document {
+	return po.document
+}
+
+// SetDocument sets the document that the print operation prints.
+func (po *PrintOperation) SetDocument(doc *Document) {
+	po.document = doc
+}
+
+// SetHeader sets the header that is printed at the top of each page. A nil header
+// removes it.
+func (po *PrintOperation) SetHeader(header *HeaderFooter) {
+	po.header = header
+}
+
+// SetFooter sets the footer that is printed at the bottom of each page. A nil footer
+// removes it.
+func (po *PrintOperation) SetFooter(footer *HeaderFooter) {
+	po.footer = footer
+}
+
+// SetFirstPageHeader sets a header that replaces the page header on the first page.
+func (po *PrintOperation) SetFirstPageHeader(header *HeaderFooter) {
+	po.firstHeader = header
+}
+
+// SetFirstPageFooter sets a footer that replaces the page footer on the first page.
+func (po *PrintOperation) SetFirstPageFooter(footer *HeaderFooter) {
+	po.firstFooter = footer
+}
+
+// SetWatermarks sets the watermarks that are drawn over every printed sheet, in the
+// order that they are drawn. Calling SetWatermarks with no arguments removes them.
+func (po *PrintOperation) SetWatermarks(watermarks ...*Watermark) {
+	po.watermarks = watermarks
+}
+
+// PageRanges returns the selection of document pages that are printed.
+func (po *PrintOperation) PageRanges() PageRanges {
+	return po.pageRanges
+}
+
+// SetPageRanges sets the selection of document pages that are printed.
+func (po *PrintOperation) SetPageRanges(pr PageRanges) {
+	po.pageRanges = pr
+}
+
+// Copies returns the number of copies that are printed and whether they are collated.
+func (po *PrintOperation) Copies() Copies {
+	return po.copies
+}
+
+// SetCopies sets the number of copies that are printed and whether they are collated.
+func (po *PrintOperation) SetCopies(c Copies) {
+	po.copies = c
+}
+
+// ManualDuplex returns the manual duplex setting, or nil if the sheets are printed on
+// one side or duplexed by the printer.
+func (po *PrintOperation) ManualDuplex() *ManualDuplex {
+	return po.manualDuplex
+}
+
+// SetManualDuplex prints both sides of the sheets in two passes on printers that cannot
+// duplex. Pass nil to turn manual duplex printing off.
+func (po *PrintOperation) SetManualDuplex(m *ManualDuplex) {
+	po.manualDuplex = m
+}
+
+// ColorConversion returns how the rendered pages are converted before they are encoded.
+func (po *PrintOperation) ColorConversion() ColorConversion {
+	return po.colorConversion
+}
+
+// SetColorConversion sets how the rendered pages are converted before they are encoded.
+func (po *PrintOperation) SetColorConversion(cc ColorConversion) {
+	po.colorConversion = cc
+}
+
+// ColorManagement returns the ICC color management that is applied to the rendered
+// pages.
+func (po *PrintOperation) ColorManagement() ColorManagement {
+	return po.colorManagement
+}
+
+// SetColorManagement sets the ICC output profile and rendering intent that the rendered
+// pages are converted with. A ColorManagement with no profile turns it off.
+func (po *PrintOperation) SetColorManagement(cm ColorManagement) {
+	po.colorManagement = cm
+}
+
+// Options returns the IPP job options that are submitted with the job, such as
+// "media-type" or "print-quality".
+func (po *PrintOperation) Options() JobOptions {
+	return po.options
+}
+
+// SetOptions sets the IPP job options that are submitted with the job. Options that are
+// required by the page setup, copies and page ranges replace these options.
+func (po *PrintOperation) SetOptions(options JobOptions) {
+	po.options = options
+}
+
+// ResolveConflicts finds the job options that conflict with a changed option, and the
+// options that the printer suggests to resolve the conflict. The conflicts are returned
+// so that they can be shown to the user; call ApplyConflicts to make the change.
+//
+// Params:
+//
+//	printer is the printer to print to.
+//	option and value are the option that is changed and its new value.
+func (po *PrintOperation) ResolveConflicts(printer *Printer, option, value string) (
+	OptionConflicts, error) {
+	options, _ := po.printOptions(printer)
+	return printer.ResolveConflicts(options, option, value)
+}
+
+// ApplyConflicts sets a changed option, and applies the options that resolve its
+// conflicts as returned by ResolveConflicts.
+//
+// Params:
+//
+//	option and value are the option that is changed and its new value.
+//	conflicts are the conflicts that ResolveConflicts returned for the change.
+func (po *PrintOperation) ApplyConflicts(option, value string, conflicts OptionConflicts) {
+	options := JobOptions{}
+	for name, v := range po.options {
+		options[name] = v
+	}
+	options[option] = value
+	po.options = conflicts.Apply(options)
+}
+
+// RenderPages paginates the operation's document and renders each page, including
+// the headers and footers. Space for the headers and footers is reserved inside the
+// imageable area of the page. Only the pages selected by the operation's PageRanges
+// are printed, but headers and footers are numbered using the document's page numbers.
+// If the PageSetupInfo has a document page size, the document is paginated at that size
+// and the pages are scaled onto the media. The pages are then imposed onto sheets using
+// the booklet or N-up setting of the PageSetupInfo, and the watermarks are drawn over
+// each sheet.
+//
+// Params:
+//
+//	pc is the print context that describes the page.
+//	printerName is the value of the {printer} header and footer placeholder.
+func (po *PrintOperation) RenderPages(pc *PrintContext, printerName string) ([]image.Image, error) {
+	return po.renderPages(pc, printerName, po.pageRanges)
+}
+
+// renderPages renders the document pages selected by pr. See RenderPages.
+func (po *PrintOperation) renderPages(pc *PrintContext, printerName string,
+	pr PageRanges) ([]image.Image, error) {
+	if po.document == nil {
+		return nil, errors.New("no document to print")
+	}
+	docPC := pc
+	if po.pageSetupInfo != nil && !po.pageSetupInfo.docSize.IsZero() {
+		docPC = newPrintContext(po.pageSetupInfo.docSize, Margins{}, pc.dpi)
+	}
+	top := maxFloat32(po.header.height(), po.firstHeader.height())
+	bottom := maxFloat32(po.footer.height(), po.firstFooter.height())
+	pages := po.document.Pages(docPC.inset(top, bottom))
+
+	info := pageInfo{pages: len(pages), date: time.Now(), title: po.document.Title(),
+		printer: printerName, user: currentUserName()}
+	for i, page := range pages {
+		info.page = i + 1
+		header, footer := po.header, po.footer
+		if i == 0 && po.firstHeader != nil {
+			header = po.firstHeader
+		}
+		if i == 0 && po.firstFooter != nil {
+			footer = po.firstFooter
+		}
+		po.drawHeaderFooter(page.(draw.Image), docPC, header, info, false)
+		po.drawHeaderFooter(page.(draw.Image), docPC, footer, info, true)
+	}
+	pages = pr.Select(pages)
+	if docPC != pc {
+		pages = po.pageSetupInfo.scaling.Apply(pages, pc)
+	}
+	switch {
+	case po.pageSetupInfo == nil:
+	case po.pageSetupInfo.booklet != nil:
+		pages = po.pageSetupInfo.booklet.Impose(pages)
+	default:
+		pages = po.pageSetupInfo.nUp.Impose(pages)
+	}
+	info.pages = len(pages)
+	for i, page := range pages {
+		info.page = i + 1
+		for _, w := range po.watermarks {
+			w.draw(page.(draw.Image), pc, info)
+		}
+	}
+	return pages, nil
+}
+
+// drawHeaderFooter draws a header at the top, or a footer at the bottom, of the
+// imageable area of a page.
+func (po *PrintOperation) drawHeaderFooter(page draw.Image, pc *PrintContext,
+	hf *HeaderFooter, info pageInfo, atBottom bool) {
+	if hf == nil {
+		return
+	}
+	band := hf.band(pc.ImageableSize().Width, info)
+	at := pc.ImageableOrigin()
+	if atBottom {
+		at.Y += pc.ImageableSize().Height - band.Size().Height
+	}
+	pc.renderRegion(page, band, band.Size(), fyne.NewPos(0, 0), at)
+}
+
+// maxFloat32 returns the larger of a and b.
+func maxFloat32(a, b float32) float32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Print renders the operation's document, encodes it, and submits it to the printer.
+// The page ranges are sent to the printer as the IPP "page-ranges" attribute if the
+// printer supports it, makes the copies, and each document page is printed on its own
+// sheet. Otherwise, the pages are selected before they are encoded. Copies are made by
+// the printer if it can print them as requested, and are generated in the document
+// otherwise. Pages are converted to the operation's ICC output profile, if it has one,
+// and then using its ColorConversion. They are always converted to grayscale for
+// printers that cannot print in color. The job is validated before the pages are
+// rendered, and the Conflicts are returned if the printer would reject it. If the job
+// cannot be validated, the error is logged and the job is submitted anyway.
+//
+// If manual duplex is set, only the fronts of the sheets are submitted before Print
+// returns. A dialog then tells the user how to reinsert the printed stack, and the
+// backs are submitted when it is confirmed. Errors printing the backs are shown in a
+// dialog. The dialog is shown in the operation's window, or in the app's first window
+// if it has none; if there is no window, an error is returned before anything is
+// printed.
+//
+// If the printer is the PDF printer, Print returns as soon as its save dialog is
+// shown. The document is written when the user chooses the file, and errors writing it
+// are shown in a dialog.
+//
+// Params:
+//
+//	printer is the printer to print to.
+//	pc is the print context that describes the page.
+//	enc converts the rendered pages into a format that the printer accepts. Virtual
+//	printers, such as the PDF printer, use their own encoder instead, and may render
+//	the pages at their own resolution.
+func (po *PrintOperation) Print(printer *Printer, pc *PrintContext, enc PageEncoder) error {
+	if printer.IsVirtual() {
+		if dpi := printer.virtual.dpi; dpi > 0 {
+			pc = newPrintContext(pc.PageSize(), pc.margins, dpi)
+		}
+		enc = printer.virtualEncoder(pc.dpi)
+	}
+	options, printerCopies := po.printOptions(printer)
+	conflicts, err := printer.Validate(options, enc.Format())
+	if err != nil {
+		fyne.LogError("Unable to validate the print job: ", err)
+	} else if conflicts != nil {
+		return conflicts
+	}
+	pr := po.pageRanges
+	if _, ok := options["page-ranges"]; ok {
+		pr = PageRanges{}
+	}
+	pages, err := po.renderPages(pc, printer.Name(), pr)
+	if err != nil {
+		return err
+	}
+	cc := po.colorConversion
+	if cc.Mode == ColorModeColor && !printer.canPrintColor() {
+		cc.Mode = ColorModeMonochrome
+	}
+	pages = cc.Apply(po.colorManagement.Apply(pages))
+	if po.manualDuplex != nil {
+		return po.printManualDuplex(printer, enc, options, pages)
+	}
+	if !printerCopies {
+		twoSided := strings.HasPrefix(options["sides"], "two-sided")
+		pages = po.copies.sequenceSides(pages, twoSided)
+	}
+	return po.submit(printer, enc, options, pages)
+}
+
+// Validate asks the printer whether it would accept the job that Print submits, so that
+// conflicting options can be shown before the job is printed.
+//
+// Params:
+//
+//	printer is the printer to print to.
+//	enc is the encoder that Print will be called with.
+//
+// Returns the options that the printer does not accept, or nil if the job is valid.
+func (po *PrintOperation) Validate(printer *Printer, enc PageEncoder) (Conflicts, error) {
+	options, _ := po.printOptions(printer)
+	return printer.Validate(options, enc.Format())
+}
+
+// printOptions returns the job options that Print submits to the printer, and whether
+// the printer makes the copies. The page ranges are only included if the printer makes
+// the copies and supports page ranges; otherwise the pages are selected before the
+// copies are made in the document.
+func (po *PrintOperation) printOptions(printer *Printer) (JobOptions, bool) {
+	options := po.jobOptions()
+	printerCopies := false
+	if po.manualDuplex == nil {
+		var copyOptions map[string]string
+		copyOptions, printerCopies = po.copies.jobOptions(printer.copySupport())
+		for name, value := range copyOptions {
+			options[name] = value
+		}
+	}
+	if value, ok := po.printerPageRanges(printerCopies); ok && printer.PageRangesSupported() {
+		options["page-ranges"] = value
+	}
+	return options, printerCopies
+}
+
+// printerPageRanges returns the IPP "page-ranges" value, and whether the page ranges
+// can be applied by the printer. They can only be applied if each document page is
+// printed on its own sheet and the printer makes the copies, because copies that are
+// generated in the document would be joined into one document that the page ranges
+// then select from.
+//
+// Params:
+//
+//	printerCopies is true if the printer makes the copies.
+func (po *PrintOperation) printerPageRanges(printerCopies bool) (string, bool) {
+	value, ok := po.pageRanges.ippValue()
+	return value, ok && printerCopies && po.manualDuplex == nil && po.onePagePerSheet()
+}
+
+// printManualDuplex submits the fronts of the sheets, and submits the backs after the
+// user has reinserted the printed stack. The copies are always generated in the
+// document so that the backs are printed in the reverse order of the fronts.
+func (po *PrintOperation) printManualDuplex(printer *Printer, enc PageEncoder,
+	options JobOptions, sides []image.Image) error {
+	window := dialogWindow(po.window)
+	if window == nil {
+		return errors.New("no window to show the manual duplex instructions in")
+	}
+	m := po.manualDuplex
+	sides = po.copies.sequence(padSides(sides), 2)
+	fronts, backs := m.Passes(sides)
+	if err := po.submit(printer, enc, options, fronts); err != nil {
+		return err
+	}
+	dialog.ShowConfirm("Manual Duplex", m.Instructions(), func(ok bool) {
+		if !ok {
+			return
+		}
+		if err := po.submit(printer, enc, options, backs); err != nil {
+			dialog.ShowError(err, window)
+		}
+	}, window)
+	return nil
+}
+
+// dialogWindow returns the window that dialogs are shown in. If window is nil, the
+// first window of the current app is used, and nil is returned if there is none.
+func dialogWindow(window fyne.Window) fyne.Window {
+	if window != nil {
+		return window
+	}
+	if app := fyne.CurrentApp(); app != nil {
+		if windows := app.Driver().AllWindows(); len(windows) > 0 {
+			return windows[0]
+		}
+	}
+	return nil
+}
+
+// submit encodes the pages and submits them to the printer as a job.
+func (po *PrintOperation) submit(printer *Printer, enc PageEncoder, options JobOptions,
+	pages []image.Image) error {
+	var buf bytes.Buffer
+	if err := enc.Encode(&buf, pages); err != nil {
+		return err
+	}
+	_, err := printer.SubmitJob(po.document.Title(), enc.Format(), options, &buf)
+	return err
+}
+
+// onePagePerSheet returns true if each document page is printed on its own sheet, so
+// that the printer's page numbers are the same as the document's.
+func (po *PrintOperation) onePagePerSheet() bool {
+	return po.pageSetupInfo == nil ||
+		(po.pageSetupInfo.booklet == nil && po.pageSetupInfo.nUp.PagesPerSheet <= 1)
+}
+
+// jobOptions returns the options set with SetOptions, and the job options that are
+// required by the PageSetupInfo settings.
+// Booklets are printed two-sided by the printer unless manual duplex is set.
+func (po *PrintOperation) jobOptions() JobOptions {
+	options := JobOptions{}
+	for name, value := range po.options {
+		options[name] = value
+	}
+	if po.pageSetupInfo != nil && po.pageSetupInfo.booklet != nil && po.manualDuplex == nil {
+		options["sides"] = po.pageSetupInfo.booklet.Sides()
+	}
+	return options
+}
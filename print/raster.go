@@ -0,0 +1,108 @@
+package print
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"io"
+)
+
+// rasterColorSpace identifies the pixel layout used when converting a rendered page
+// into raster rows.
+type rasterColorSpace int
+
+const (
+	rasterGray8 rasterColorSpace = iota // 8-bit luminance, 0 is black and 255 is white
+	rasterRGB24                         // 8 bits per channel red, green, blue
+)
+
+// bytesPerPixel returns the number of bytes used to store each pixel in the color space.
+func (cs rasterColorSpace) bytesPerPixel() int {
+	if cs == rasterRGB24 {
+		return 3
+	}
+	return 1
+}
+
+// rasterRows converts a page image into rows of packed pixels in the specified
+// color space. Transparent pixels are composited onto white paper.
+func rasterRows(img image.Image, cs rasterColorSpace) [][]byte {
+	b := img.Bounds()
+	bpp := cs.bytesPerPixel()
+	rows := make([][]byte, b.Dy())
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := make([]byte, b.Dx()*bpp)
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl := onWhite(img.At(x, y))
+			i := (x - b.Min.X) * bpp
+			if cs == rasterRGB24 {
+				row[i] = r
+				row[i+1] = g
+				row[i+2] = bl
+			} else {
+				row[i] = color.GrayModel.Convert(color.RGBA{R: r, G: g, B: bl, A: 0xff}).(color.Gray).Y
+			}
+		}
+		rows[y-b.Min.Y] = row
+	}
+	return rows
+}
+
+// onWhite returns the 8-bit red, green, and blue values of the color after it has
+// been composited onto a white background.
+func onWhite(c color.Color) (uint8, uint8, uint8) {
+	r, g, b, a := c.RGBA()
+	white := 0xffff - a
+	return uint8((r + white) >> 8), uint8((g + white) >> 8), uint8((b + white) >> 8)
+}
+
+// encodeRasterLines writes the rows of a page using the run-length compression that
+// is shared by PWG raster and Apple raster (URF). Each group of identical rows is
+// preceded by a line repeat count, and the pixels in the row are written as runs of
+// repeated pixels or of literal pixels.
+func encodeRasterLines(w io.Writer, rows [][]byte, bytesPerPixel int) error {
+	var buf bytes.Buffer
+	for y := 0; y < len(rows); {
+		repeat := 1
+		for y+repeat < len(rows) && repeat < 256 && bytes.Equal(rows[y], rows[y+repeat]) {
+			repeat++
+		}
+		buf.WriteByte(byte(repeat - 1))
+		encodeRasterLine(&buf, rows[y], bytesPerPixel)
+		y += repeat
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// encodeRasterLine compresses a single row of pixels. A control byte of 0 to 127
+// indicates that the following pixel is repeated one to 128 times; a control byte
+// of 129 to 255 indicates that 128 to 2 literal pixels follow.
+func encodeRasterLine(buf *bytes.Buffer, row []byte, bpp int) {
+	n := len(row) / bpp
+	pixel := func(i int) []byte { return row[i*bpp : (i+1)*bpp] }
+	for i := 0; i < n; {
+		run := 1
+		for i+run < n && run < 128 && bytes.Equal(pixel(i), pixel(i+run)) {
+			run++
+		}
+		if run > 1 || i == n-1 {
+			buf.WriteByte(byte(run - 1))
+			buf.Write(pixel(i))
+			i += run
+			continue
+		}
+		count := 1
+		for i+count < n && count < 128 &&
+			(i+count == n-1 || !bytes.Equal(pixel(i+count), pixel(i+count+1))) {
+			count++
+		}
+		if count == 1 {
+			buf.WriteByte(0)
+		} else {
+			buf.WriteByte(byte(257 - count))
+		}
+		buf.Write(row[i*bpp : (i+count)*bpp])
+		i += count
+	}
+}
@@ -0,0 +1,68 @@
+package print
+
+import "fmt"
+
+// ReadyMedia is a media size that is loaded in a printer.
+type ReadyMedia struct {
+	MediaSize
+	// Source is the IPP "media-source" keyword of the tray that holds the media, for
+	// example "tray-1". It is empty if the printer does not report the tray.
+	Source string
+}
+
+// loadedMedia is the size of the media in a printer tray, in hundredths of a
+// millimeter.
+type loadedMedia struct {
+	width, length int
+	source        string
+}
+
+// mediaSizeTolerance is the largest difference between two media dimensions that are
+// treated as the same size, in hundredths of a millimeter.
+const mediaSizeTolerance = 100
+
+// mediaSource returns the tray that holds media of the specified size, or an empty
+// string if no tray holds it.
+//
+// Params:
+//
+//	loaded is the media in each of the printer's trays.
+//	width and length are the media size in hundredths of a millimeter.
+func mediaSource(loaded []loadedMedia, width, length int) string {
+	within := func(a, b int) bool {
+		return a-b <= mediaSizeTolerance && b-a <= mediaSizeTolerance
+	}
+	for _, m := range loaded {
+		if within(m.width, width) && within(m.length, length) {
+			return m.source
+		}
+	}
+	return ""
+}
+
+// mediaSizeOptions returns the names shown for the media sizes in the page setup
+// dialog, and the index of the preferred media size. Media sizes that are loaded are
+// marked with the tray that holds them, and the first of them is preferred. The
+// preferred index is -1 if no media is loaded.
+func mediaSizeOptions(sizes MediaSizes, ready []ReadyMedia) ([]string, int) {
+	names := make([]string, len(sizes))
+	preferred := -1
+	for i := range sizes {
+		names[i] = sizes[i].LocalName()
+		for _, r := range ready {
+			if r.LocalName() != names[i] {
+				continue
+			}
+			if preferred < 0 {
+				preferred = i
+			}
+			if r.Source != "" {
+				names[i] = fmt.Sprintf("%s (loaded in %s)", names[i], r.Source)
+			} else {
+				names[i] += " (loaded)"
+			}
+			break
+		}
+	}
+	return names, preferred
+}
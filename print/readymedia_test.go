@@ -0,0 +1,31 @@
+package print
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaSource(t *testing.T) {
+	loaded := []loadedMedia{{21590, 27940, "tray-1"}, {21000, 29700, "tray-2"}}
+	assert.Equal(t, "tray-2", mediaSource(loaded, 21000, 29700))
+	assert.Equal(t, "tray-1", mediaSource(loaded, 21600, 27900))
+	assert.Equal(t, "", mediaSource(loaded, 29700, 42000))
+}
+
+func TestMediaSizeOptions(t *testing.T) {
+	var sizes MediaSizes
+	for _, m := range standardMedia[:3] {
+		sizes.Add(newStandardMediaSize(m))
+	}
+	names, preferred := mediaSizeOptions(sizes, nil)
+	assert.Equal(t, []string{"US Letter", "US Legal", "Executive"}, names)
+	assert.Equal(t, -1, preferred)
+
+	ready := []ReadyMedia{{MediaSize: sizes[1], Source: "tray-1"},
+		{MediaSize: sizes[2]}}
+	names, preferred = mediaSizeOptions(sizes, ready)
+	assert.Equal(t, []string{"US Letter", "US Legal (loaded in tray-1)",
+		"Executive (loaded)"}, names)
+	assert.Equal(t, 1, preferred)
+}
@@ -0,0 +1,30 @@
+package print
+
+import (
+	"image"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/software"
+)
+
+// pointsPerInch is the number of fyne units per inch when printing. Fyne units are
+// treated as typographic points so that objects print at a predictable physical size.
+const pointsPerInch = 72
+
+// renderObject renders a canvas object at the specified size, in fyne units, to an image.
+// The background is left transparent so that the page is composited onto white paper
+// when it is encoded.
+//
+// Params:
+//
+//	obj is the object to render.
+//	size is the size to lay out the object at.
+//	dpi is the resolution of the image in dots per inch.
+func renderObject(obj fyne.CanvasObject, size fyne.Size, dpi int) image.Image {
+	c := software.NewTransparentCanvas()
+	c.SetPadded(false)
+	c.SetScale(float32(dpi) / pointsPerInch)
+	c.SetContent(obj)
+	c.Resize(size)
+	return c.Capture()
+}
@@ -0,0 +1,133 @@
+package print
+
+import (
+	"fmt"
+	"image"
+
+	"fyne.io/fyne/v2"
+	xdraw "golang.org/x/image/draw"
+)
+
+// PrintScaling is the way that document pages are scaled when their size differs from
+// the media. The modes match the values of the IPP "print-scaling" attribute.
+type PrintScaling int
+
+const (
+	// ScalingAuto fills the page on borderless media and fits the imageable area
+	// otherwise.
+	ScalingAuto PrintScaling = iota
+	// ScalingAutoFit fits pages that are larger than the imageable area and does not
+	// scale smaller pages.
+	ScalingAutoFit
+	// ScalingFit scales pages to fit the imageable area without cropping.
+	ScalingFit
+	// ScalingFill scales pages to fill the whole page, cropping any content that does
+	// not fit.
+	ScalingFill
+	// ScalingNone prints pages at their own size, or at Scaling.Percent of it,
+	// cropping any content that does not fit.
+	ScalingNone
+)
+
+// printScalingNames are the IPP keywords for the PrintScaling values.
+var printScalingNames = []string{"auto", "auto-fit", "fit", "fill", "none"}
+
+// String returns the IPP keyword for the scaling mode.
+func (s PrintScaling) String() string {
+	if s < 0 || int(s) >= len(printScalingNames) {
+		return printScalingNames[ScalingAuto]
+	}
+	return printScalingNames[s]
+}
+
+// ParsePrintScaling converts an IPP "print-scaling" keyword to a PrintScaling value.
+func ParsePrintScaling(keyword string) (PrintScaling, error) {
+	for i, name := range printScalingNames {
+		if name == keyword {
+			return PrintScaling(i), nil
+		}
+	}
+	return ScalingAuto, fmt.Errorf("unknown print-scaling value: %s", keyword)
+}
+
+// Scaling describes how document pages are scaled and positioned on the media.
+type Scaling struct {
+	Mode PrintScaling
+	// Percent is the scale used by ScalingNone. Zero prints at 100 percent.
+	Percent float32
+	// TopLeft places pages at the top left of the imageable area, or of the page for
+	// ScalingFill, instead of centering them.
+	TopLeft bool
+	// Offset moves pages after they have been placed.
+	Offset fyne.Position
+}
+
+// rect returns the rectangle, in fyne units, that a document page is drawn in on the
+// media described by a print context.
+//
+// Params:
+//
+//	doc is the size of the document page.
+//	pc is the print context for the media.
+func (s Scaling) rect(doc fyne.Size, pc *PrintContext) (fyne.Position, fyne.Size) {
+	origin, area := pc.ImageableOrigin(), pc.ImageableSize()
+	mode := s.Mode
+	switch mode {
+	case ScalingAuto:
+		mode = ScalingFit
+		if pc.margins == (Margins{}) {
+			mode = ScalingFill
+		}
+	case ScalingAutoFit:
+		mode = ScalingFit
+		if doc.Width <= area.Width && doc.Height <= area.Height {
+			mode = ScalingNone
+		}
+	}
+
+	var scale float32 = 1
+	switch mode {
+	case ScalingFit:
+		scale = minFloat32(area.Width/doc.Width, area.Height/doc.Height)
+	case ScalingFill:
+		origin, area = fyne.NewPos(0, 0), pc.PageSize()
+		scale = maxFloat32(area.Width/doc.Width, area.Height/doc.Height)
+	case ScalingNone:
+		if s.Percent > 0 {
+			scale = s.Percent / 100
+		}
+	}
+	size := fyne.NewSize(doc.Width*scale, doc.Height*scale)
+	if !s.TopLeft {
+		origin = origin.AddXY((area.Width-size.Width)/2, (area.Height-size.Height)/2)
+	}
+	return origin.Add(s.Offset), size
+}
+
+// Apply scales and positions each document page onto a page of the media described by
+// a print context. The document pages must be rendered at the print context's
+// resolution.
+func (s Scaling) Apply(pages []image.Image, pc *PrintContext) []image.Image {
+	scaled := make([]image.Image, len(pages))
+	for i, page := range pages {
+		size := page.Bounds().Size()
+		doc := fyne.NewSize(float32(size.X)*pointsPerInch/float32(pc.dpi),
+			float32(size.Y)*pointsPerInch/float32(pc.dpi))
+		pos, sz := s.rect(doc, pc)
+		min := image.Pt(pc.toPixels(pos.X), pc.toPixels(pos.Y))
+		target := image.Rectangle{Min: min,
+			Max: min.Add(image.Pt(pc.toPixels(sz.Width), pc.toPixels(sz.Height)))}
+		dst := pc.newPageImage()
+		xdraw.ApproxBiLinear.Scale(dst, target, page, page.Bounds(), xdraw.Over, nil)
+		scaled[i] = dst
+	}
+	return scaled
+}
+
+// minFloat32 returns the smaller of a and b.
+func minFloat32(a, b float32) float32 {
+	if a < b {
+		return a
+	}
+	return b
+}
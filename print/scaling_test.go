@@ -0,0 +1,60 @@
+package print
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePrintScaling(t *testing.T) {
+	for _, s := range []PrintScaling{ScalingAuto, ScalingAutoFit, ScalingFit, ScalingFill,
+		ScalingNone} {
+		parsed, err := ParsePrintScaling(s.String())
+		assert.Nil(t, err)
+		assert.Equal(t, s, parsed)
+	}
+	_, err := ParsePrintScaling("stretch")
+	assert.NotNil(t, err)
+	assert.Equal(t, "auto-fit", ScalingAutoFit.String())
+}
+
+func TestScaling_Rect(t *testing.T) {
+	pc := newPrintContext(fyne.NewSize(200, 100), Margins{top: 10, bottom: 10, left: 10,
+		right: 10}, 72)
+	doc := fyne.NewSize(100, 100)
+
+	pos, size := Scaling{Mode: ScalingFit}.rect(doc, pc)
+	assert.Equal(t, fyne.NewPos(60, 10), pos)
+	assert.Equal(t, fyne.NewSize(80, 80), size)
+
+	pos, size = Scaling{Mode: ScalingFill}.rect(doc, pc)
+	assert.Equal(t, fyne.NewPos(0, -50), pos)
+	assert.Equal(t, fyne.NewSize(200, 200), size)
+
+	pos, size = Scaling{Mode: ScalingNone, Percent: 50, TopLeft: true,
+		Offset: fyne.NewPos(5, 5)}.rect(doc, pc)
+	assert.Equal(t, fyne.NewPos(15, 15), pos)
+	assert.Equal(t, fyne.NewSize(50, 50), size)
+
+	_, size = Scaling{Mode: ScalingAutoFit}.rect(fyne.NewSize(50, 50), pc)
+	assert.Equal(t, fyne.NewSize(50, 50), size)
+	_, size = Scaling{Mode: ScalingAutoFit}.rect(doc, pc)
+	assert.Equal(t, fyne.NewSize(80, 80), size)
+
+	_, size = Scaling{}.rect(doc, pc)
+	assert.Equal(t, fyne.NewSize(80, 80), size)
+	_, size = Scaling{}.rect(doc, newPrintContext(fyne.NewSize(200, 100), Margins{}, 72))
+	assert.Equal(t, fyne.NewSize(200, 200), size)
+}
+
+func TestScaling_Apply(t *testing.T) {
+	pc := newPrintContext(fyne.NewSize(40, 20), Margins{}, 72)
+	pages := Scaling{Mode: ScalingFit}.Apply([]image.Image{newTestPage(10, 10, color.Black)}, pc)
+	assert.Len(t, pages, 1)
+	assert.Equal(t, image.Rect(0, 0, 40, 20), pages[0].Bounds())
+	assert.Equal(t, uint32(0), alphaAt(pages[0], 5, 10))
+	assert.Equal(t, uint32(0xffff), alphaAt(pages[0], 20, 10))
+}
@@ -0,0 +1,303 @@
+//go:build !windows
+
+package print
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/OpenPrinting/goipp"
+)
+
+// Event types that can be subscribed to. The values are IPP "notify-events" keywords.
+const (
+	EventJobCreated          = "job-created"
+	EventJobCompleted        = "job-completed"
+	EventJobStateChanged     = "job-state-changed"
+	EventJobProgress         = "job-progress"
+	EventPrinterStateChanged = "printer-state-changed"
+	EventPrinterAdded        = "printer-added"
+	EventPrinterDeleted      = "printer-deleted"
+	EventPrinterChanged      = "printer-changed"
+)
+
+// subscriptionLease is the lease duration requested for subscriptions, in seconds.
+// Zero asks the server for a subscription that does not expire.
+const subscriptionLease = 0
+
+// defaultNotificationInterval is the time between Get-Notifications requests when the
+// server does not suggest one.
+const defaultNotificationInterval = 30 * time.Second
+
+// minNotificationInterval is the shortest time between Get-Notifications requests, so
+// that a server that suggests no wait is not polled continuously.
+const minNotificationInterval = time.Second
+
+// Event is an event notification delivered for a subscription.
+type Event struct {
+	// Type is the event type, for example EventJobCompleted.
+	Type           string
+	SubscriptionID int
+	SequenceNumber int
+	// Text is a human readable description of the event.
+	Text        string
+	PrinterName string
+	PrinterURI  string
+	// PrinterStatus is the state of the printer when the event occurred.
+	PrinterStatus PrinterStatus
+	// JobID is the ID of the job for job events, and zero for other events.
+	JobID int
+	// JobState is the IPP "job-state" of the job for job events.
+	JobState int
+}
+
+// Subscription is an IPP event subscription whose events are retrieved from the print
+// server with the "ippget" pull method.
+type Subscription struct {
+	id  int
+	uri string
+	// mu guards the fields below it.
+	mu sync.Mutex
+	// sequence is the sequence number of the next event to retrieve.
+	sequence int
+	// events is the channel returned by Events, and stop ends its goroutine.
+	events chan Event
+	stop   chan struct{}
+	err    error
+}
+
+// SubscribePrinter creates a subscription for events of the printer and its jobs.
+//
+// Params:
+//
+//	events are the event types to subscribe to, for example EventPrinterStateChanged.
+func (p *Printer) SubscribePrinter(events ...string) (*Subscription, error) {
+	if p.virtual != nil {
+		return nil, errors.New("virtual printers do not support subscriptions")
+	}
+	return subscribe(p.printerURI(), goipp.OpCreatePrinterSubscriptions, 0, events)
+}
+
+// SubscribeJob creates a subscription for events of a job on the printer.
+//
+// Params:
+//
+//	jobID is the ID of the job, as returned by SubmitJob.
+//	events are the event types to subscribe to, for example EventJobCompleted.
+func (p *Printer) SubscribeJob(jobID int, events ...string) (*Subscription, error) {
+	if p.virtual != nil {
+		return nil, errors.New("virtual printers do not support subscriptions")
+	}
+	return subscribe(p.printerURI(), goipp.OpCreateJobSubscriptions, jobID, events)
+}
+
+// SubscribeServer creates a subscription for events of every printer on the local print
+// server, such as EventPrinterAdded.
+//
+// Params:
+//
+//	events are the event types to subscribe to.
+func SubscribeServer(events ...string) (*Subscription, error) {
+	return subscribe(localCupsURI+"/", goipp.OpCreatePrinterSubscriptions, 0, events)
+}
+
+// subscribe sends a Create-Printer-Subscriptions or Create-Job-Subscriptions request to
+// uri and returns the created subscription.
+func subscribe(uri string, op goipp.Op, jobID int, events []string) (*Subscription, error) {
+	if len(events) == 0 {
+		return nil, errors.New("no events to subscribe to")
+	}
+	m := newRequest(op, uri)
+	m.Operation.Add(goipp.MakeAttribute("requesting-user-name",
+		goipp.TagName, goipp.String(requestingUserName())))
+	notifyEvents := goipp.Attribute{Name: "notify-events"}
+	for _, event := range events {
+		notifyEvents.Values.Add(goipp.TagKeyword, goipp.String(event))
+	}
+	m.Subscription.Add(notifyEvents)
+	m.Subscription.Add(goipp.MakeAttribute("notify-pull-method",
+		goipp.TagKeyword, goipp.String("ippget")))
+	if jobID != 0 {
+		m.Subscription.Add(goipp.MakeAttribute("notify-job-id",
+			goipp.TagInteger, goipp.Integer(jobID)))
+	} else {
+		m.Subscription.Add(goipp.MakeAttribute("notify-lease-duration",
+			goipp.TagInteger, goipp.Integer(subscriptionLease)))
+	}
+	response, err := sendRequest(uri, m)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range response.Groups {
+		if group.Tag != goipp.TagSubscriptionGroup {
+			continue
+		}
+		for _, attr := range group.Attrs {
+			if attr.Name != "notify-subscription-id" || len(attr.Values) == 0 {
+				continue
+			}
+			if id, ok := attr.Values[0].V.(goipp.Integer); ok {
+				return &Subscription{id: int(id), uri: uri, sequence: 1}, nil
+			}
+		}
+	}
+	return nil, errors.New("the print server did not return a subscription ID")
+}
+
+// ID returns the subscription ID assigned by the print server.
+func (s *Subscription) ID() int {
+	return s.id
+}
+
+// Notifications retrieves the events that have occurred since the previous call.
+//
+// Returns the events, and the time that the print server asks clients to wait before
+// requesting notifications again. The time is at least one second.
+func (s *Subscription) Notifications() ([]Event, time.Duration, error) {
+	s.mu.Lock()
+	sequence := s.sequence
+	s.mu.Unlock()
+	m := newRequest(goipp.OpGetNotifications, s.uri)
+	m.Operation.Add(goipp.MakeAttribute("requesting-user-name",
+		goipp.TagName, goipp.String(requestingUserName())))
+	m.Operation.Add(goipp.MakeAttribute("notify-subscription-ids",
+		goipp.TagInteger, goipp.Integer(s.id)))
+	m.Operation.Add(goipp.MakeAttribute("notify-sequence-numbers",
+		goipp.TagInteger, goipp.Integer(sequence)))
+	response, err := sendRequest(s.uri, m)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	interval := defaultNotificationInterval
+	var events []Event
+	for _, group := range response.Groups {
+		switch group.Tag {
+		case goipp.TagOperationGroup:
+			for _, attr := range group.Attrs {
+				if attr.Name != "notify-get-interval" || len(attr.Values) == 0 {
+					continue
+				}
+				if seconds, ok := attr.Values[0].V.(goipp.Integer); ok {
+					interval = time.Duration(seconds) * time.Second
+				}
+			}
+		case goipp.TagEventNotificationGroup:
+			event := newEvent(group.Attrs)
+			if event.SubscriptionID != s.id {
+				continue
+			}
+			s.mu.Lock()
+			if event.SequenceNumber >= s.sequence {
+				s.sequence = event.SequenceNumber + 1
+			}
+			s.mu.Unlock()
+			events = append(events, event)
+		}
+	}
+	if interval < minNotificationInterval {
+		interval = minNotificationInterval
+	}
+	return events, interval, nil
+}
+
+// newEvent creates an event from the attributes of an event notification group.
+func newEvent(attrs goipp.Attributes) Event {
+	var event Event
+	integer := func(attr goipp.Attribute) int {
+		if n, ok := attr.Values[0].V.(goipp.Integer); ok {
+			return int(n)
+		}
+		return 0
+	}
+	for _, attr := range attrs {
+		if len(attr.Values) == 0 {
+			continue
+		}
+		switch attr.Name {
+		case "notify-subscribed-event":
+			event.Type = attr.Values[0].V.String()
+		case "notify-subscription-id":
+			event.SubscriptionID = integer(attr)
+		case "notify-sequence-number":
+			event.SequenceNumber = integer(attr)
+		case "notify-text":
+			event.Text = attr.Values[0].V.String()
+		case "printer-name":
+			event.PrinterName = attr.Values[0].V.String()
+		case "printer-uri", "notify-printer-uri":
+			event.PrinterURI = attr.Values[0].V.String()
+		case "notify-job-id":
+			event.JobID = integer(attr)
+		case "job-state":
+			event.JobState = integer(attr)
+		}
+	}
+	addStatusAttributes(&event.PrinterStatus, attrs)
+	return event
+}
+
+// Events starts retrieving the subscription's events in the background and returns the
+// channel that they are delivered on. The channel is closed when the subscription is
+// canceled or events cannot be retrieved; Err then returns the reason. Calling Events
+// again before the subscription is canceled returns the same channel.
+func (s *Subscription) Events() <-chan Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return s.events
+	}
+	events := make(chan Event)
+	stop := make(chan struct{})
+	s.events, s.stop = events, stop
+	go func() {
+		defer close(events)
+		for {
+			notifications, interval, err := s.Notifications()
+			if err != nil {
+				s.mu.Lock()
+				s.err = err
+				s.mu.Unlock()
+				return
+			}
+			for _, event := range notifications {
+				select {
+				case events <- event:
+				case <-stop:
+					return
+				}
+			}
+			select {
+			case <-stop:
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+	return events
+}
+
+// Err returns the error that stopped the delivery of events, or nil.
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Cancel stops the delivery of events and cancels the subscription on the print server.
+func (s *Subscription) Cancel() error {
+	s.mu.Lock()
+	if s.stop != nil {
+		close(s.stop)
+		s.events, s.stop = nil, nil
+	}
+	s.mu.Unlock()
+	m := newRequest(goipp.OpCancelSubscription, s.uri)
+	m.Operation.Add(goipp.MakeAttribute("requesting-user-name",
+		goipp.TagName, goipp.String(requestingUserName())))
+	m.Operation.Add(goipp.MakeAttribute("notify-subscription-id",
+		goipp.TagInteger, goipp.Integer(s.id)))
+	_, err := sendRequest(s.uri, m)
+	return err
+}
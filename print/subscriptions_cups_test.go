@@ -0,0 +1,121 @@
+//go:build !windows
+
+package print
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/OpenPrinting/goipp"
+	"github.com/stretchr/testify/assert"
+)
+
+// ippStandIn is a local IPP server that supports the subscription operations.
+type ippStandIn struct {
+	mu       sync.Mutex
+	events   []goipp.Attributes
+	canceled bool
+	// sequences are the notify-sequence-numbers of the Get-Notifications requests
+	sequences []int
+}
+
+func (s *ippStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request goipp.Message
+	if err := request.Decode(r.Body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	response := goipp.NewResponse(goipp.DefaultVersion, goipp.StatusOk, request.RequestID)
+	response.Operation.Add(goipp.MakeAttribute("attributes-charset",
+		goipp.TagCharset, goipp.String("utf-8")))
+	switch goipp.Op(request.Code) {
+	case goipp.OpCreatePrinterSubscriptions:
+		response.Subscription.Add(goipp.MakeAttribute("notify-subscription-id",
+			goipp.TagInteger, goipp.Integer(42)))
+	case goipp.OpGetNotifications:
+		for _, attr := range request.Operation {
+			if attr.Name == "notify-sequence-numbers" {
+				s.sequences = append(s.sequences, int(attr.Values[0].V.(goipp.Integer)))
+			}
+		}
+		response.Operation.Add(goipp.MakeAttribute("notify-get-interval",
+			goipp.TagInteger, goipp.Integer(0)))
+		response.Groups = goipp.Groups{{Tag: goipp.TagOperationGroup, Attrs: response.Operation}}
+		for _, event := range s.events {
+			response.Groups = append(response.Groups,
+				goipp.Group{Tag: goipp.TagEventNotificationGroup, Attrs: event})
+		}
+		s.events = nil
+	case goipp.OpCancelSubscription:
+		s.canceled = true
+	default:
+		response.Code = goipp.Code(goipp.StatusErrorOperationNotSupported)
+	}
+	w.Header().Set("Content-Type", goipp.ContentType)
+	_ = response.Encode(w)
+}
+
+func newTestEvent(sequence int, event string) goipp.Attributes {
+	attrs := goipp.Attributes{}
+	attrs.Add(goipp.MakeAttribute("notify-subscription-id",
+		goipp.TagInteger, goipp.Integer(42)))
+	attrs.Add(goipp.MakeAttribute("notify-sequence-number",
+		goipp.TagInteger, goipp.Integer(sequence)))
+	attrs.Add(goipp.MakeAttribute("notify-subscribed-event",
+		goipp.TagKeyword, goipp.String(event)))
+	attrs.Add(goipp.MakeAttribute("printer-name",
+		goipp.TagName, goipp.String("Printer1")))
+	attrs.Add(goipp.MakeAttribute("printer-state",
+		goipp.TagEnum, goipp.Integer(PrinterStopped)))
+	attrs.Add(goipp.MakeAttribute("printer-state-reasons",
+		goipp.TagKeyword, goipp.String("media-empty-error")))
+	return attrs
+}
+
+func TestSubscription(t *testing.T) {
+	standIn := &ippStandIn{}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	_, err := subscribe(server.URL, goipp.OpCreatePrinterSubscriptions, 0, nil)
+	assert.NotNil(t, err)
+	_, err = subscribe(server.URL, goipp.OpCreateJobSubscriptions, 7,
+		[]string{EventJobCompleted})
+	assert.NotNil(t, err)
+
+	s, err := subscribe(server.URL, goipp.OpCreatePrinterSubscriptions, 0,
+		[]string{EventPrinterStateChanged, EventPrinterAdded})
+	assert.Nil(t, err)
+	assert.Equal(t, 42, s.ID())
+
+	standIn.events = []goipp.Attributes{newTestEvent(1, EventPrinterStateChanged),
+		newTestEvent(2, EventPrinterAdded)}
+	events, interval, err := s.Notifications()
+	assert.Nil(t, err)
+	// the stand-in's notify-get-interval of zero is raised to the minimum
+	assert.Equal(t, minNotificationInterval, interval)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, EventPrinterStateChanged, events[0].Type)
+	assert.Equal(t, "Printer1", events[0].PrinterName)
+	assert.True(t, events[0].PrinterStatus.IsOutOfPaper())
+	assert.Equal(t, 2, events[1].SequenceNumber)
+
+	standIn.mu.Lock()
+	standIn.events = []goipp.Attributes{newTestEvent(3, EventPrinterAdded)}
+	standIn.mu.Unlock()
+	delivered := s.Events()
+	assert.True(t, delivered == s.Events())
+	event := <-delivered
+	assert.Equal(t, 3, event.SequenceNumber)
+	assert.Nil(t, s.Cancel())
+
+	standIn.mu.Lock()
+	defer standIn.mu.Unlock()
+	assert.True(t, standIn.canceled)
+	assert.Equal(t, []int{1, 3}, standIn.sequences[:2])
+	assert.Nil(t, s.Err())
+}
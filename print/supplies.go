@@ -0,0 +1,233 @@
+package print
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Special supply levels reported in the IPP "marker-levels" attribute.
+const (
+	SupplyLevelUnavailable = -1 // the printer cannot measure the level
+	SupplyLevelUnknown     = -2 // the level is not known
+	SupplyLevelSomeLeft    = -3 // the level is not known, but the supply is not empty
+)
+
+// Supply is a toner, ink or other marker supply of a printer.
+type Supply struct {
+	Name string
+	// Color is the color of the marker. Supplies with more than one color, such as
+	// tri-color ink cartridges, use the first color.
+	Color color.Color
+	// Level is the remaining supply in percent, or one of the special supply levels.
+	Level int
+	// LowLevel is the level in percent at which the supply is considered to be low.
+	LowLevel int
+	// Type is the IPP marker type, for example "toner" or "ink-cartridge".
+	Type string
+}
+
+// IsLow returns true if the supply level is known and at or below its low level.
+func (s Supply) IsLow() bool {
+	return s.Level >= 0 && s.Level <= s.LowLevel
+}
+
+// LevelString returns the supply level as text, for example "40%".
+func (s Supply) LevelString() string {
+	switch {
+	case s.Level >= 0:
+		return fmt.Sprintf("%d%%", s.Level)
+	case s.Level == SupplyLevelSomeLeft:
+		return "OK"
+	}
+	return "Unknown"
+}
+
+// newSupplies creates the supplies from the string values of the IPP marker attributes.
+// The attributes hold one value for each supply.
+func newSupplies(names, colors, levels, lowLevels, types []string) []Supply {
+	value := func(values []string, i int) string {
+		if i < len(values) {
+			return values[i]
+		}
+		return ""
+	}
+	number := func(values []string, i int, missing int) int {
+		n, err := strconv.Atoi(value(values, i))
+		if err != nil {
+			return missing
+		}
+		return n
+	}
+	supplies := make([]Supply, len(names))
+	for i, name := range names {
+		supplies[i] = Supply{
+			Name:     name,
+			Color:    parseMarkerColor(value(colors, i)),
+			Level:    number(levels, i, SupplyLevelUnknown),
+			LowLevel: number(lowLevels, i, 0),
+			Type:     value(types, i),
+		}
+	}
+	return supplies
+}
+
+// parseMarkerColor converts a marker-colors value, such as "#00FFFF" or
+// "#00FFFF#FF00FF#FFFF00", to a color. Values that are not colors return gray.
+func parseMarkerColor(value string) color.Color {
+	gray := color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}
+	if !strings.HasPrefix(value, "#") || len(value) < 7 {
+		return gray
+	}
+	rgb, err := strconv.ParseUint(value[1:7], 16, 32)
+	if err != nil {
+		return gray
+	}
+	return color.NRGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xff}
+}
+
+// Declare conformity with the Widget interface
+var _ fyne.Widget = (*SuppliesWidget)(nil)
+
+// SuppliesWidget shows the levels of a printer's supplies as colored bars.
+type SuppliesWidget struct {
+	widget.BaseWidget
+	supplies []Supply
+}
+
+// NewSuppliesWidget creates a widget that shows the supply levels.
+//
+// Params:
+//
+//	supplies are the supplies to show, usually the result of Printer.Supplies.
+func NewSuppliesWidget(supplies []Supply) *SuppliesWidget {
+	w := &SuppliesWidget{supplies: supplies}
+	w.ExtendBaseWidget(w)
+	return w
+}
+
+// SetSupplies replaces the supplies that the widget shows.
+func (w *SuppliesWidget) SetSupplies(supplies []Supply) {
+	w.supplies = supplies
+	w.Refresh()
+}
+
+// CreateRenderer returns the renderer for the widget.
+func (w *SuppliesWidget) CreateRenderer() fyne.WidgetRenderer {
+	r := &suppliesRenderer{widget: w}
+	r.update()
+	return r
+}
+
+// supplyBar holds the canvas objects that show one supply.
+type supplyBar struct {
+	label      *widget.Label
+	background *canvas.Rectangle
+	level      *canvas.Rectangle
+	percent    *widget.Label
+}
+
+// suppliesRenderer lays out a label, a bar and the level text for each supply.
+type suppliesRenderer struct {
+	widget *SuppliesWidget
+	bars   []supplyBar
+}
+
+// barWidth is the width of the supply level bars.
+const barWidth = 120
+
+// update creates the canvas objects for the widget's supplies.
+func (r *suppliesRenderer) update() {
+	r.bars = nil
+	for _, s := range r.widget.supplies {
+		name := s.Name
+		if s.IsLow() {
+			name += " (low)"
+		}
+		background := canvas.NewRectangle(theme.Color(theme.ColorNameInputBackground))
+		background.StrokeColor = theme.Color(theme.ColorNameForeground)
+		background.StrokeWidth = 1
+		r.bars = append(r.bars, supplyBar{
+			label:      widget.NewLabel(name),
+			background: background,
+			level:      canvas.NewRectangle(s.Color),
+			percent:    widget.NewLabel(s.LevelString()),
+		})
+	}
+}
+
+// Destroy does nothing because the renderer holds no resources.
+func (r *suppliesRenderer) Destroy() {}
+
+// Layout places one row for each supply.
+func (r *suppliesRenderer) Layout(size fyne.Size) {
+	labelWidth := r.labelWidth()
+	y := float32(0)
+	for i, bar := range r.bars {
+		rowHeight := bar.label.MinSize().Height
+		bar.label.Move(fyne.NewPos(0, y))
+		bar.label.Resize(fyne.NewSize(labelWidth, rowHeight))
+
+		barHeight := rowHeight / 2
+		barPos := fyne.NewPos(labelWidth, y+(rowHeight-barHeight)/2)
+		bar.background.Move(barPos)
+		bar.background.Resize(fyne.NewSize(barWidth, barHeight))
+		level := r.widget.supplies[i].Level
+		if level < 0 {
+			level = 0
+		} else if level > 100 {
+			level = 100
+		}
+		bar.level.Move(barPos)
+		bar.level.Resize(fyne.NewSize(barWidth*float32(level)/100, barHeight))
+
+		bar.percent.Move(fyne.NewPos(labelWidth+barWidth, y))
+		bar.percent.Resize(bar.percent.MinSize())
+		y += rowHeight
+	}
+}
+
+// labelWidth returns the width of the widest supply name.
+func (r *suppliesRenderer) labelWidth() float32 {
+	width := float32(0)
+	for _, bar := range r.bars {
+		if w := bar.label.MinSize().Width; w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// MinSize returns the size needed to show every supply.
+func (r *suppliesRenderer) MinSize() fyne.Size {
+	width, height := float32(0), float32(0)
+	for _, bar := range r.bars {
+		if w := bar.percent.MinSize().Width; w > width {
+			width = w
+		}
+		height += bar.label.MinSize().Height
+	}
+	return fyne.NewSize(r.labelWidth()+barWidth+width, height)
+}
+
+// Objects returns the canvas objects of every supply.
+func (r *suppliesRenderer) Objects() []fyne.CanvasObject {
+	var objects []fyne.CanvasObject
+	for _, bar := range r.bars {
+		objects = append(objects, bar.label, bar.background, bar.level, bar.percent)
+	}
+	return objects
+}
+
+// Refresh recreates the canvas objects from the widget's supplies.
+func (r *suppliesRenderer) Refresh() {
+	r.update()
+	r.Layout(r.widget.Size())
+	canvas.Refresh(r.widget)
+}
@@ -0,0 +1,43 @@
+package print
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSupplies(t *testing.T) {
+	supplies := newSupplies([]string{"Black Toner", "Tri-color Ink", "Waste"},
+		[]string{"#000000", "#00FFFF#FF00FF#FFFF00", "none"},
+		[]string{"8", "-3"},
+		[]string{"10", "15", "5"},
+		[]string{"toner", "ink-cartridge", "waste-toner"})
+	assert.Equal(t, 3, len(supplies))
+	assert.Equal(t, Supply{Name: "Black Toner", Color: color.NRGBA{A: 0xff}, Level: 8,
+		LowLevel: 10, Type: "toner"}, supplies[0])
+	assert.True(t, supplies[0].IsLow())
+	assert.Equal(t, "8%", supplies[0].LevelString())
+
+	assert.Equal(t, color.NRGBA{G: 0xff, B: 0xff, A: 0xff}, supplies[1].Color)
+	assert.False(t, supplies[1].IsLow())
+	assert.Equal(t, "OK", supplies[1].LevelString())
+
+	assert.Equal(t, SupplyLevelUnknown, supplies[2].Level)
+	assert.Equal(t, color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff}, supplies[2].Color)
+	assert.Equal(t, "Unknown", supplies[2].LevelString())
+}
+
+func TestSuppliesWidget(t *testing.T) {
+	test.NewApp()
+	w := NewSuppliesWidget([]Supply{{Name: "Cyan", Level: 50}})
+	r := test.WidgetRenderer(w)
+	assert.Equal(t, 4, len(r.Objects()))
+
+	w.SetSupplies([]Supply{{Name: "Cyan", Level: 50}, {Name: "Magenta", Level: 25}})
+	assert.Equal(t, 8, len(r.Objects()))
+	w.Resize(r.MinSize())
+	level := r.Objects()[6]
+	assert.Equal(t, float32(barWidth)/4, level.Size().Width)
+}
@@ -0,0 +1,126 @@
+package print
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"image"
+	"io"
+)
+
+// TIFFFormat is the MIME type of TIFF documents.
+const TIFFFormat = "image/tiff"
+
+// TIFF field types.
+const (
+	tiffShort    = 3
+	tiffLong     = 4
+	tiffRational = 5
+)
+
+// tiffEntry is an entry in a TIFF image file directory. Values that do not fit in the
+// four bytes of the entry are written after the directory.
+type tiffEntry struct {
+	tag, typ uint16
+	count    uint32
+	value    []byte
+}
+
+// TIFFEncoder writes pages as a multi-page TIFF document. Each page is stored as a
+// single Deflate compressed strip.
+type TIFFEncoder struct {
+	// Resolution is the resolution of the rendered pages in dots per inch. It is
+	// recorded in each page so that the pages have the correct physical size.
+	Resolution int
+}
+
+// Format returns the MIME type of TIFF documents.
+func (e *TIFFEncoder) Format() string {
+	return TIFFFormat
+}
+
+// Encode writes the pages to w as a multi-page TIFF document. Grayscale pages are
+// stored as 8-bit grayscale images and all other pages as 24-bit RGB images.
+// Transparent pixels are composited onto white paper.
+func (e *TIFFEncoder) Encode(w io.Writer, pages []image.Image) error {
+	le := binary.LittleEndian
+	var buf bytes.Buffer
+	buf.WriteString("II*\x00\x00\x00\x00\x00")
+	// nextIFD is the position of the offset that points to the next directory
+	nextIFD := 4
+	dpi := e.Resolution
+	if dpi <= 0 {
+		dpi = 300
+	}
+	short := func(v ...uint16) []byte {
+		b := make([]byte, 2*len(v))
+		for i, s := range v {
+			le.PutUint16(b[2*i:], s)
+		}
+		return b
+	}
+	long := func(v uint32) []byte { return le.AppendUint32(nil, v) }
+	resolution := le.AppendUint32(long(uint32(dpi)), 1)
+
+	for i, page := range pages {
+		b := page.Bounds()
+		cs, photometric, bits := rasterRGB24, uint16(2), short(8, 8, 8)
+		if _, ok := page.(*image.Gray); ok {
+			cs, photometric, bits = rasterGray8, 1, short(8)
+		}
+		stripOffset := buf.Len()
+		zw := zlib.NewWriter(&buf)
+		for _, row := range rasterRows(page, cs) {
+			if _, err := zw.Write(row); err != nil {
+				return err
+			}
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		stripBytes := buf.Len() - stripOffset
+		if buf.Len()%2 == 1 {
+			buf.WriteByte(0)
+		}
+
+		entries := []tiffEntry{
+			{254, tiffLong, 1, long(2)},
+			{256, tiffLong, 1, long(uint32(b.Dx()))},
+			{257, tiffLong, 1, long(uint32(b.Dy()))},
+			{258, tiffShort, uint32(len(bits) / 2), bits},
+			{259, tiffShort, 1, short(8)},
+			{262, tiffShort, 1, short(photometric)},
+			{273, tiffLong, 1, long(uint32(stripOffset))},
+			{277, tiffShort, 1, short(uint16(len(bits) / 2))},
+			{278, tiffLong, 1, long(uint32(b.Dy()))},
+			{279, tiffLong, 1, long(uint32(stripBytes))},
+			{282, tiffRational, 1, resolution},
+			{283, tiffRational, 1, resolution},
+			{284, tiffShort, 1, short(1)},
+			{296, tiffShort, 1, short(2)},
+			{297, tiffShort, 2, short(uint16(i), uint16(len(pages)))},
+		}
+		ifd := buf.Len()
+		le.PutUint32(buf.Bytes()[nextIFD:], uint32(ifd))
+		extra := ifd + 2 + 12*len(entries) + 4
+		var extraData []byte
+		buf.Write(short(uint16(len(entries))))
+		for _, entry := range entries {
+			buf.Write(short(entry.tag, entry.typ))
+			buf.Write(long(entry.count))
+			if len(entry.value) <= 4 {
+				value := make([]byte, 4)
+				copy(value, entry.value)
+				buf.Write(value)
+				continue
+			}
+			buf.Write(long(uint32(extra + len(extraData))))
+			extraData = append(extraData, entry.value...)
+		}
+		nextIFD = buf.Len()
+		buf.Write(long(0))
+		buf.Write(extraData)
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
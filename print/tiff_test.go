@@ -0,0 +1,36 @@
+package print
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/tiff"
+)
+
+func TestTIFFEncoder_Encode(t *testing.T) {
+	pages := []image.Image{newTestPage(30, 20, color.Black), image.NewGray(image.Rect(0, 0, 10, 5))}
+	var buf bytes.Buffer
+	e := &TIFFEncoder{Resolution: 150}
+	assert.Equal(t, TIFFFormat, e.Format())
+	assert.Nil(t, e.Encode(&buf, pages))
+
+	// the first page is decoded by the standard TIFF decoder
+	img, err := tiff.Decode(bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 30, 20), img.Bounds())
+	r, g, b, _ := img.At(5, 5).RGBA()
+	assert.Equal(t, [3]uint32{0, 0, 0}, [3]uint32{r, g, b})
+
+	// follow the directory chain to count the pages
+	data := buf.Bytes()
+	count := 0
+	for ifd := binary.LittleEndian.Uint32(data[4:]); ifd != 0; count++ {
+		entries := int(binary.LittleEndian.Uint16(data[ifd:]))
+		ifd = binary.LittleEndian.Uint32(data[int(ifd)+2+12*entries:])
+	}
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,180 @@
+package print
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// URFFormat is the MIME type for Apple raster documents.
+const URFFormat = "image/urf"
+
+// URFColorSpace is a color space keyword from the printer's urf-supported attribute.
+type URFColorSpace string
+
+const (
+	URFSGray8   URFColorSpace = "W8"       // 8-bit sGray
+	URFSRGB24   URFColorSpace = "SRGB24"   // 24-bit sRGB
+	URFDevGray8 URFColorSpace = "DEVW8"    // 8-bit device gray
+	URFDevRGB24 URFColorSpace = "DEVRGB24" // 24-bit device RGB
+)
+
+// URFDuplex is the duplex mode written into each URF page header.
+type URFDuplex uint8
+
+const (
+	URFSimplex         URFDuplex = 1 // one-sided printing
+	URFDuplexShortEdge URFDuplex = 2 // two-sided, flipped on the short edge (tumble)
+	URFDuplexLongEdge  URFDuplex = 3 // two-sided, flipped on the long edge
+)
+
+// URFSupported contains the values parsed from a printer's urf-supported attribute.
+type URFSupported struct {
+	Versions    []string
+	Resolutions []int
+	ColorSpaces []URFColorSpace
+	Qualities   []int
+	Duplex      bool
+}
+
+// ParseURFSupported parses the keywords of an urf-supported attribute. Keywords that
+// are not used by the URF encoder are ignored.
+func ParseURFSupported(keywords []string) URFSupported {
+	var u URFSupported
+	for _, k := range keywords {
+		switch {
+		case strings.HasPrefix(k, "V"):
+			u.Versions = append(u.Versions, k[1:])
+		case strings.HasPrefix(k, "RS"):
+			u.Resolutions = append(u.Resolutions, parseURFNumbers(k[2:])...)
+		case strings.HasPrefix(k, "PQ"):
+			u.Qualities = append(u.Qualities, parseURFNumbers(k[2:])...)
+		case strings.HasPrefix(k, "DM"):
+			u.Duplex = true
+		case k == string(URFSRGB24), k == string(URFDevGray8), k == string(URFDevRGB24):
+			u.ColorSpaces = append(u.ColorSpaces, URFColorSpace(k))
+		case strings.HasPrefix(k, string(URFSGray8)):
+			// W8 may be combined with other bit depths, as in "W8-16".
+			u.ColorSpaces = append(u.ColorSpaces, URFSGray8)
+		}
+	}
+	sort.Ints(u.Resolutions)
+	return u
+}
+
+// parseURFNumbers parses a dash separated list of numbers such as "300-600".
+func parseURFNumbers(s string) []int {
+	var nums []int
+	for _, f := range strings.Split(s, "-") {
+		n, err := strconv.Atoi(f)
+		if err == nil {
+			nums = append(nums, n)
+		}
+	}
+	return nums
+}
+
+// SupportsColorSpace returns true if the printer accepts the specified color space.
+func (u URFSupported) SupportsColorSpace(cs URFColorSpace) bool {
+	for _, c := range u.ColorSpaces {
+		if c == cs {
+			return true
+		}
+	}
+	return false
+}
+
+// Declare conformity with PageEncoder interface
+var _ PageEncoder = (*URFEncoder)(nil)
+
+// URFEncoder writes rendered pages as an Apple raster (image/urf) document.
+type URFEncoder struct {
+	Resolution int
+	ColorSpace URFColorSpace
+	Duplex     URFDuplex
+	Quality    int
+}
+
+// NewURFEncoder creates a URFEncoder using the highest resolution and the best color
+// space that the printer supports.
+//
+// Params:
+//
+//	supported contains the values parsed from the printer's urf-supported attribute.
+func NewURFEncoder(supported URFSupported) *URFEncoder {
+	e := &URFEncoder{Resolution: 300, ColorSpace: URFSGray8, Duplex: URFSimplex}
+	if len(supported.Resolutions) > 0 {
+		e.Resolution = supported.Resolutions[len(supported.Resolutions)-1]
+	}
+	for _, cs := range []URFColorSpace{URFSRGB24, URFDevRGB24, URFSGray8, URFDevGray8} {
+		if supported.SupportsColorSpace(cs) {
+			e.ColorSpace = cs
+			break
+		}
+	}
+	if len(supported.Qualities) > 0 {
+		e.Quality = supported.Qualities[len(supported.Qualities)/2]
+	}
+	return e
+}
+
+// Format returns the MIME type of the documents created by the encoder.
+func (e *URFEncoder) Format() string {
+	return URFFormat
+}
+
+// Encode writes the pages to w. Each page image must already be rendered at the
+// encoder's resolution.
+func (e *URFEncoder) Encode(w io.Writer, pages []image.Image) error {
+	if len(pages) == 0 {
+		return errors.New("no pages to encode")
+	}
+	header := make([]byte, 12)
+	copy(header, "UNIRAST\x00")
+	binary.BigEndian.PutUint32(header[8:], uint32(len(pages)))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	for i, page := range pages {
+		if err := e.encodePage(w, page); err != nil {
+			return fmt.Errorf("encoding page %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// encodePage writes the 32 byte URF page header followed by the compressed page rows.
+func (e *URFEncoder) encodePage(w io.Writer, page image.Image) error {
+	cs := rasterGray8
+	var urfCS byte
+	switch e.ColorSpace {
+	case URFSGray8:
+		urfCS = 0
+	case URFSRGB24:
+		cs, urfCS = rasterRGB24, 1
+	case URFDevGray8:
+		urfCS = 4
+	case URFDevRGB24:
+		cs, urfCS = rasterRGB24, 5
+	default:
+		return fmt.Errorf("unsupported URF color space %q", e.ColorSpace)
+	}
+	b := page.Bounds()
+	header := make([]byte, 32)
+	header[0] = byte(cs.bytesPerPixel() * 8)
+	header[1] = urfCS
+	header[2] = byte(e.Duplex)
+	header[3] = byte(e.Quality)
+	binary.BigEndian.PutUint32(header[12:], uint32(b.Dx()))
+	binary.BigEndian.PutUint32(header[16:], uint32(b.Dy()))
+	binary.BigEndian.PutUint32(header[20:], uint32(e.Resolution))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	return encodeRasterLines(w, rasterRows(page, cs), cs.bytesPerPixel())
+}
@@ -0,0 +1,72 @@
+package print
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseURFSupported(t *testing.T) {
+	u := ParseURFSupported([]string{"V1.4", "CP1", "DM1", "IS1", "MT1-2-3-5", "OB10",
+		"PQ3-4-5", "RS600-300", "SRGB24", "W8-16"})
+	assert.Equal(t, []string{"1.4"}, u.Versions)
+	assert.Equal(t, []int{300, 600}, u.Resolutions)
+	assert.Equal(t, []int{3, 4, 5}, u.Qualities)
+	assert.Equal(t, []URFColorSpace{URFSRGB24, URFSGray8}, u.ColorSpaces)
+	assert.True(t, u.Duplex)
+}
+
+func TestNewURFEncoder(t *testing.T) {
+	e := NewURFEncoder(ParseURFSupported([]string{"RS300-600", "W8", "SRGB24", "PQ3-4-5"}))
+	assert.Equal(t, 600, e.Resolution)
+	assert.Equal(t, URFSRGB24, e.ColorSpace)
+	assert.Equal(t, 4, e.Quality)
+	assert.Equal(t, URFSimplex, e.Duplex)
+
+	e = NewURFEncoder(URFSupported{})
+	assert.Equal(t, 300, e.Resolution)
+	assert.Equal(t, URFSGray8, e.ColorSpace)
+}
+
+func TestURFEncoder_Encode(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		img.SetGray(x, 0, color.Gray{Y: 0xff})
+		img.SetGray(x, 1, color.Gray{Y: 0xff})
+	}
+	e := &URFEncoder{Resolution: 300, ColorSpace: URFSGray8, Duplex: URFSimplex}
+	var buf bytes.Buffer
+	err := e.Encode(&buf, []image.Image{img})
+	assert.Nil(t, err)
+	data := buf.Bytes()
+	assert.Equal(t, "UNIRAST\x00", string(data[:8]))
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(data[8:12]))
+	page := data[12:]
+	assert.Equal(t, byte(8), page[0])
+	assert.Equal(t, byte(URFSimplex), page[2])
+	assert.Equal(t, uint32(4), binary.BigEndian.Uint32(page[12:16]))
+	assert.Equal(t, uint32(2), binary.BigEndian.Uint32(page[16:20]))
+	assert.Equal(t, uint32(300), binary.BigEndian.Uint32(page[20:24]))
+	// two identical rows of four white pixels
+	assert.Equal(t, []byte{1, 3, 0xff}, page[32:])
+}
+
+func TestURFEncoder_EncodeNoPages(t *testing.T) {
+	e := &URFEncoder{}
+	err := e.Encode(&bytes.Buffer{}, nil)
+	assert.NotNil(t, err)
+}
+
+func TestEncodeRasterLine(t *testing.T) {
+	var buf bytes.Buffer
+	encodeRasterLine(&buf, []byte{1, 2, 3, 3, 3, 4}, 1)
+	assert.Equal(t, []byte{255, 1, 2, 2, 3, 0, 4}, buf.Bytes())
+
+	buf.Reset()
+	encodeRasterLine(&buf, []byte{1, 2, 2}, 1)
+	assert.Equal(t, []byte{0, 1, 1, 2}, buf.Bytes())
+}
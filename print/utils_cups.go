@@ -4,8 +4,11 @@ package print
 
 import (
 	"bytes"
+	"fmt"
 	"net/http"
 	"os"
+	"os/user"
+	"strings"
 
 	"github.com/OpenPrinting/goipp"
 )
@@ -25,7 +28,21 @@ func createGroupsFromMessage(message *goipp.Message) (*[]goipp.Group, error) {
 }
 
 // generateRequest creates and encodes an ipp request based on the arguments to the function.
+// More than one attribute may be requested by separating the names with spaces.
 func GenerateRequest(op goipp.Op, printerUri string, attributes string) ([]byte, error) {
+	m := newRequest(op, printerUri)
+	requested := goipp.Attribute{Name: "requested-attributes"}
+	for _, name := range strings.Fields(attributes) {
+		requested.Values.Add(goipp.TagKeyword, goipp.String(name))
+	}
+	m.Operation.Add(requested)
+
+	return m.EncodeBytes()
+}
+
+// newRequest creates an ipp request with the operation attributes that every request
+// for a printer needs.
+func newRequest(op goipp.Op, printerUri string) *goipp.Message {
 	m := goipp.NewRequest(goipp.DefaultVersion, op, 1)
 	m.Operation.Add(goipp.MakeAttribute("attributes-charset",
 		goipp.TagCharset, goipp.String("utf-8")))
@@ -35,10 +52,38 @@ func GenerateRequest(op goipp.Op, printerUri string, attributes string) ([]byte,
 		goipp.TagLanguage, goipp.String("en-us")))
 	m.Operation.Add(goipp.MakeAttribute("printer-uri",
 		goipp.TagURI, goipp.String(printerUri)))
-	m.Operation.Add(goipp.MakeAttribute("requested-attributes",
-		goipp.TagKeyword, goipp.String(attributes)))
+	return m
+}
 
-	return m.EncodeBytes()
+// sendRequest posts an ipp request to the specified URI and returns the response.
+// Responses with an error status are returned together with an error.
+func sendRequest(uri string, m *goipp.Message) (*goipp.Message, error) {
+	request, err := m.EncodeBytes()
+	if err != nil {
+		return nil, err
+	}
+	response, err := http.Post(uri, goipp.ContentType, bytes.NewBuffer(request))
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	var msg goipp.Message
+	if err = msg.Decode(response.Body); err != nil {
+		return nil, err
+	}
+	if status := goipp.Status(msg.Code); status >= 0x0100 {
+		return &msg, fmt.Errorf("%s: %s", goipp.Op(m.Code), status)
+	}
+	return &msg, nil
+}
+
+// requestingUserName returns the user name that is sent in requests that the print
+// server associates with their user, such as subscriptions.
+func requestingUserName() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return "anonymous"
 }
 
 // getResponseGroups posts a message to the specified URI, retrieves the response, and
@@ -61,3 +106,117 @@ func getResponseGroups(op goipp.Op, uri string, attributes string) (*[]goipp.Gro
 	groups, err := createGroupsFromMessage(&msg)
 	return groups, err
 }
+
+// attributeStrings returns the string form of every value of the named attribute
+// found in the printer groups of an IPP response.
+func attributeStrings(groups *[]goipp.Group, name string) []string {
+	var values []string
+	for _, group := range *groups {
+		if group.Tag != goipp.TagPrinterGroup {
+			continue
+		}
+		for _, attr := range group.Attrs {
+			if attr.Name != name {
+				continue
+			}
+			for _, v := range attr.Values {
+				values = append(values, v.V.String())
+			}
+		}
+	}
+	return values
+}
+
+// parsePrinterStatus returns the printer status found in the printer groups of an IPP
+// response.
+func parsePrinterStatus(groups *[]goipp.Group) PrinterStatus {
+	var status PrinterStatus
+	for _, group := range *groups {
+		if group.Tag == goipp.TagPrinterGroup {
+			addStatusAttributes(&status, group.Attrs)
+		}
+	}
+	return status
+}
+
+// addStatusAttributes sets the fields of a printer status from the printer state
+// attributes in attrs. Other attributes are ignored.
+func addStatusAttributes(status *PrinterStatus, attrs goipp.Attributes) {
+	for _, attr := range attrs {
+		if len(attr.Values) == 0 {
+			continue
+		}
+		switch attr.Name {
+		case "printer-state":
+			if state, ok := attr.Values[0].V.(goipp.Integer); ok {
+				status.State = PrinterState(state)
+			}
+		case "printer-state-reasons":
+			var reasons []string
+			for _, v := range attr.Values {
+				reasons = append(reasons, v.V.String())
+			}
+			status.Reasons = newStateReasons(reasons)
+		case "printer-state-message":
+			status.Message = attr.Values[0].V.String()
+		case "printer-is-accepting-jobs":
+			if accepting, ok := attr.Values[0].V.(goipp.Boolean); ok {
+				status.AcceptingJobs = bool(accepting)
+			}
+		}
+	}
+}
+
+// parseMediaColReady returns the size and tray of each media collection in the
+// media-col-ready attribute found in the printer groups of an IPP response.
+func parseMediaColReady(groups *[]goipp.Group) []loadedMedia {
+	var loaded []loadedMedia
+	for _, group := range *groups {
+		if group.Tag != goipp.TagPrinterGroup {
+			continue
+		}
+		for _, attr := range group.Attrs {
+			if attr.Name != "media-col-ready" {
+				continue
+			}
+			for _, v := range attr.Values {
+				col, ok := v.V.(goipp.Collection)
+				if !ok {
+					continue
+				}
+				var m loadedMedia
+				for _, member := range col {
+					if len(member.Values) == 0 {
+						continue
+					}
+					switch member.Name {
+					case "media-source":
+						m.source = member.Values[0].V.String()
+					case "media-size":
+						size, ok := member.Values[0].V.(goipp.Collection)
+						if !ok {
+							continue
+						}
+						for _, dim := range size {
+							if len(dim.Values) == 0 {
+								continue
+							}
+							n, ok := dim.Values[0].V.(goipp.Integer)
+							if !ok {
+								continue
+							}
+							switch dim.Name {
+							case "x-dimension":
+								m.width = int(n)
+							case "y-dimension":
+								m.length = int(n)
+							}
+						}
+					}
+				}
+				loaded = append(loaded, m)
+			}
+		}
+	}
+	return loaded
+}
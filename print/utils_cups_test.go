@@ -117,3 +117,52 @@ func createTestPrintersResponse() *goipp.Message {
 
 	return m
 }
+
+func TestGenerateRequest_RequestedAttributes(t *testing.T) {
+	data, err := GenerateRequest(goipp.OpGetPrinterAttributes, localCupsURI,
+		"printer-state printer-state-reasons")
+	assert.Nil(t, err)
+	var m goipp.Message
+	assert.Nil(t, m.DecodeBytes(data))
+	for _, attr := range m.Operation {
+		if attr.Name == "requested-attributes" {
+			assert.Equal(t, 2, len(attr.Values))
+			assert.Equal(t, "printer-state-reasons", attr.Values[1].V.String())
+		}
+	}
+}
+
+func TestParsePrinterStatus(t *testing.T) {
+	attrs := goipp.Attributes{}
+	attrs.Add(goipp.MakeAttribute("printer-state", goipp.TagEnum, goipp.Integer(5)))
+	reasons := goipp.MakeAttribute("printer-state-reasons", goipp.TagKeyword,
+		goipp.String("media-empty-error"))
+	reasons.Values.Add(goipp.TagKeyword, goipp.String("toner-low-warning"))
+	attrs.Add(reasons)
+	attrs.Add(goipp.MakeAttribute("printer-state-message", goipp.TagText,
+		goipp.String("Load paper in tray 1")))
+	attrs.Add(goipp.MakeAttribute("printer-is-accepting-jobs", goipp.TagBoolean,
+		goipp.Boolean(true)))
+	groups := &[]goipp.Group{{Tag: goipp.TagPrinterGroup, Attrs: attrs}}
+
+	status := parsePrinterStatus(groups)
+	assert.Equal(t, PrinterStopped, status.State)
+	assert.Equal(t, []string{"media-empty-error", "toner-low-warning"}, status.Reasons)
+	assert.Equal(t, "Load paper in tray 1", status.Message)
+	assert.True(t, status.AcceptingJobs)
+	assert.True(t, status.IsOutOfPaper())
+}
+
+func TestParseMediaColReady(t *testing.T) {
+	size := goipp.Collection{}
+	size.Add(goipp.MakeAttribute("x-dimension", goipp.TagInteger, goipp.Integer(21000)))
+	size.Add(goipp.MakeAttribute("y-dimension", goipp.TagInteger, goipp.Integer(29700)))
+	col := goipp.Collection{}
+	col.Add(goipp.MakeAttribute("media-size", goipp.TagBeginCollection, size))
+	col.Add(goipp.MakeAttribute("media-source", goipp.TagKeyword, goipp.String("tray-2")))
+	attrs := goipp.Attributes{}
+	attrs.Add(goipp.MakeAttribute("media-col-ready", goipp.TagBeginCollection, col))
+	groups := &[]goipp.Group{{Tag: goipp.TagPrinterGroup, Attrs: attrs}}
+
+	assert.Equal(t, []loadedMedia{{21000, 29700, "tray-2"}}, parseMediaColReady(groups))
+}
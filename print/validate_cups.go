@@ -0,0 +1,128 @@
+//go:build !windows
+
+package print
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/OpenPrinting/goipp"
+)
+
+// integerJobOptions are the job options whose values are IPP integers.
+var integerJobOptions = map[string]bool{
+	"copies":       true,
+	"job-priority": true,
+	"number-up":    true,
+}
+
+// enumJobOptions are the job options whose values are IPP enums.
+var enumJobOptions = map[string]bool{
+	"finishings":            true,
+	"orientation-requested": true,
+	"print-quality":         true,
+}
+
+// Validate asks the printer whether it would accept a job with the options and
+// document format, using the IPP Validate-Job operation. Virtual printers accept every
+// job.
+//
+// Params:
+//
+//	options are the job options that the job will be submitted with.
+//	format is the MIME type of the document, for example URFFormat.
+//
+// Returns the options that the printer does not accept, or nil if the job is valid.
+// An error is returned if the printer cannot be asked.
+func (p *Printer) Validate(options JobOptions, format string) (Conflicts, error) {
+	if p.virtual != nil {
+		return nil, nil
+	}
+	return validateJob(p.printerURI(), options, format)
+}
+
+// validateJob sends a Validate-Job request to uri and converts the unsupported
+// attributes in the response to conflicts.
+func validateJob(uri string, options JobOptions, format string) (Conflicts, error) {
+	m := newRequest(goipp.OpValidateJob, uri)
+	m.Operation.Add(goipp.MakeAttribute("requesting-user-name",
+		goipp.TagName, goipp.String(requestingUserName())))
+	if format != "" {
+		m.Operation.Add(goipp.MakeAttribute("document-format",
+			goipp.TagMimeType, goipp.String(format)))
+	}
+	m.Job = goipp.Attributes{}
+	for _, name := range options.names() {
+		m.Job.Add(jobAttribute(name, options[name]))
+	}
+	response, err := sendRequest(uri, m)
+	if err == nil {
+		return nil, nil
+	}
+	if response == nil || goipp.Status(response.Code) >= 0x0500 {
+		return nil, err
+	}
+
+	var conflicts Conflicts
+	message := err.Error()
+	for _, group := range response.Groups {
+		for _, attr := range group.Attrs {
+			switch {
+			case group.Tag == goipp.TagOperationGroup && attr.Name == "status-message" &&
+				len(attr.Values) > 0:
+				message = attr.Values[0].V.String()
+			case group.Tag == goipp.TagUnsupportedGroup:
+				value, ok := options[attr.Name]
+				if attr.Name == "document-format" {
+					value, ok = format, true
+				}
+				if !ok && len(attr.Values) > 0 {
+					value = attr.Values[0].V.String()
+				}
+				conflicts = append(conflicts, newConflict(attr.Name, value))
+			}
+		}
+	}
+	if len(conflicts) == 0 {
+		conflicts = Conflicts{{Message: message}}
+	}
+	return conflicts, nil
+}
+
+// jobAttribute converts a job option to an IPP attribute with the value type that the
+// option requires. Options that are not known to need another type are keywords.
+func jobAttribute(name, value string) goipp.Attribute {
+	attr := goipp.Attribute{Name: name}
+	switch {
+	case integerJobOptions[name] || enumJobOptions[name]:
+		tag := goipp.TagInteger
+		if enumJobOptions[name] {
+			tag = goipp.TagEnum
+		}
+		for _, v := range strings.Split(value, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil {
+				return goipp.MakeAttribute(name, goipp.TagKeyword, goipp.String(value))
+			}
+			attr.Values.Add(tag, goipp.Integer(n))
+		}
+	case name == "page-ranges":
+		for _, r := range strings.Split(value, ",") {
+			first, last, _ := strings.Cut(strings.TrimSpace(r), "-")
+			lower, err := strconv.Atoi(first)
+			upper, err2 := strconv.Atoi(last)
+			if last == "" {
+				upper, err2 = lower, nil
+			}
+			if err != nil || err2 != nil {
+				return goipp.MakeAttribute(name, goipp.TagKeyword, goipp.String(value))
+			}
+			attr.Values.Add(goipp.TagRange, goipp.Range{Lower: lower, Upper: upper})
+		}
+	case value == "true" || value == "false":
+		attr.Values.Add(goipp.TagBoolean, goipp.Boolean(value == "true"))
+	default:
+		attr.Values.Add(goipp.TagKeyword, goipp.String(value))
+	}
+	return attr
+}
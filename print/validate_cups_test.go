@@ -0,0 +1,97 @@
+//go:build !windows
+
+package print
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/OpenPrinting/goipp"
+	"github.com/stretchr/testify/assert"
+)
+
+// validateStandIn is a local IPP server that does not support short edge two-sided
+// printing, two-sided printing on transparencies, or PDF documents.
+func validateStandIn(w http.ResponseWriter, r *http.Request) {
+	var request goipp.Message
+	if err := request.Decode(r.Body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	response := goipp.NewResponse(goipp.DefaultVersion, goipp.StatusOk, request.RequestID)
+	response.Operation = goipp.Attributes{}
+	response.Operation.Add(goipp.MakeAttribute("attributes-charset",
+		goipp.TagCharset, goipp.String("utf-8")))
+	if goipp.Op(request.Code) == goipp.OpGetPrinterAttributes {
+		response.Printer = goipp.Attributes{}
+		response.Printer.Add(goipp.MakeAttribute("media-type-default",
+			goipp.TagKeyword, goipp.String("stationery")))
+		w.Header().Set("Content-Type", goipp.ContentType)
+		_ = response.Encode(w)
+		return
+	}
+	for _, attr := range request.Operation {
+		if attr.Name == "document-format" && attr.Values[0].V.String() == PDFFormat {
+			response.Code = goipp.Code(goipp.StatusErrorDocumentFormatNotSupported)
+			response.Operation.Add(goipp.MakeAttribute("status-message",
+				goipp.TagText, goipp.String("PDF is not supported")))
+		}
+	}
+	job := map[string]goipp.Attribute{}
+	for _, attr := range request.Job {
+		job[attr.Name] = attr
+	}
+	unsupported := goipp.Attributes{}
+	sides := job["sides"]
+	if len(sides.Values) > 0 && sides.Values[0].V.String() == "two-sided-short-edge" {
+		unsupported.Add(sides)
+	}
+	mediaType := job["media-type"]
+	if len(sides.Values) > 0 && sides.Values[0].V.String() != "one-sided" &&
+		len(mediaType.Values) > 0 && mediaType.Values[0].V.String() == "transparency" {
+		unsupported.Add(mediaType)
+	}
+	if len(unsupported) > 0 {
+		response.Code = goipp.Code(goipp.StatusErrorAttributesOrValues)
+		response.Unsupported = unsupported
+	}
+	w.Header().Set("Content-Type", goipp.ContentType)
+	_ = response.Encode(w)
+}
+
+func TestValidateJob(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(validateStandIn))
+	defer server.Close()
+
+	conflicts, err := validateJob(server.URL, JobOptions{"sides": "one-sided",
+		"copies": "2"}, URFFormat)
+	assert.Nil(t, err)
+	assert.Nil(t, conflicts)
+
+	conflicts, err = validateJob(server.URL, JobOptions{"sides": "two-sided-short-edge"},
+		URFFormat)
+	assert.Nil(t, err)
+	assert.Equal(t, Conflicts{newConflict("sides", "two-sided-short-edge")}, conflicts)
+
+	conflicts, err = validateJob(server.URL, JobOptions{}, PDFFormat)
+	assert.Nil(t, err)
+	assert.Equal(t, Conflicts{{Message: "PDF is not supported"}}, conflicts)
+
+	_, err = validateJob("http://localhost:632", JobOptions{}, URFFormat)
+	assert.NotNil(t, err)
+}
+
+func TestJobAttribute(t *testing.T) {
+	attr := jobAttribute("copies", "3")
+	assert.Equal(t, goipp.TagInteger, attr.Values[0].T)
+	attr = jobAttribute("print-quality", "5")
+	assert.Equal(t, goipp.TagEnum, attr.Values[0].T)
+	attr = jobAttribute("page-ranges", "1-3,5")
+	assert.Equal(t, goipp.Range{Lower: 1, Upper: 3}, attr.Values[0].V)
+	assert.Equal(t, goipp.Range{Lower: 5, Upper: 5}, attr.Values[1].V)
+	attr = jobAttribute("sides", "two-sided-short-edge")
+	assert.Equal(t, goipp.TagKeyword, attr.Values[0].T)
+	attr = jobAttribute("print-color-mode", "monochrome")
+	assert.Equal(t, goipp.String("monochrome"), attr.Values[0].V)
+}
@@ -0,0 +1,116 @@
+package print
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+)
+
+// PDFPrinterName is the name of the virtual printer that saves jobs as PDF files.
+const PDFPrinterName = "Save as PDF"
+
+// standardMediaSize is a media size in the catalog that virtual printers expose.
+type standardMediaSize struct {
+	pwgName   string
+	localName string
+	width     float32 // millimeters
+	length    float32 // millimeters
+	dmPaper   uint16  // the Windows DMPAPER value
+}
+
+// standardMedia is the catalog of common media sizes that virtual printers expose.
+// Virtual printers have no margins.
+var standardMedia = []standardMediaSize{
+	{"na_letter_8.5x11in", "US Letter", 215.9, 279.4, 1},
+	{"na_legal_8.5x14in", "US Legal", 215.9, 355.6, 5},
+	{"na_executive_7.25x10.5in", "Executive", 184.15, 266.7, 7},
+	{"na_ledger_11x17in", "Tabloid", 279.4, 431.8, 3},
+	{"iso_a3_297x420mm", "A3", 297, 420, 8},
+	{"iso_a4_210x297mm", "A4", 210, 297, 9},
+	{"iso_a5_148x210mm", "A5", 148, 210, 11},
+	{"jis_b5_182x257mm", "B5 (JIS)", 182, 257, 13},
+	{"na_number-10_4.125x9.5in", "Envelope #10", 104.775, 241.3, 20},
+	{"iso_dl_110x220mm", "Envelope DL", 110, 220, 27},
+}
+
+// virtualOptions are the job options that virtual printers support.
+var virtualOptions = PrinterOptions{
+	OptionSides:     {Supported: []string{"one-sided"}, Default: "one-sided"},
+	OptionColorMode: {Supported: []string{"color", "monochrome"}, Default: "color"},
+}
+
+// virtualPrinter is a printer that is implemented by the print package instead of the
+// print system.
+type virtualPrinter struct {
+	name string
+	// dpi is the resolution that pages are rendered at. Zero uses the resolution of the
+	// print context.
+	dpi int
+	// newEncoder returns the encoder for pages rendered at the resolution.
+	newEncoder func(dpi int) PageEncoder
+	// write saves a submitted document.
+	write func(title string, document io.Reader) error
+}
+
+// IsVirtual returns true if the printer is a virtual printer, such as the PDF printer,
+// rather than a printer of the print system.
+func (p *Printer) IsVirtual() bool {
+	return p.virtual != nil
+}
+
+// virtualEncoder returns the encoder that a virtual printer saves pages with, or nil
+// for printers of the print system.
+func (p *Printer) virtualEncoder(dpi int) PageEncoder {
+	if p.virtual == nil {
+		return nil
+	}
+	return p.virtual.newEncoder(dpi)
+}
+
+// NewPDFPrinter creates a virtual printer that saves jobs as PDF files. The file is
+// chosen with a save dialog when the job is submitted. SubmitJob returns when the
+// dialog is shown, and the file is written after the user has chosen it.
+//
+// Params:
+//
+//	window is the window that shows the save dialog. If it is nil, the first window
+//	of the current app is used.
+func NewPDFPrinter(window fyne.Window) *Printer {
+	return newVirtualPrinter(&virtualPrinter{
+		name:       PDFPrinterName,
+		newEncoder: func(dpi int) PageEncoder { return &PDFEncoder{Resolution: dpi} },
+		write: func(title string, document io.Reader) error {
+			return saveWithDialog(window, title+".pdf", document)
+		},
+	})
+}
+
+// saveWithDialog shows a save dialog and writes the document to the chosen file.
+// Errors writing the file are shown in a dialog.
+func saveWithDialog(window fyne.Window, fileName string, document io.Reader) error {
+	window = dialogWindow(window)
+	if window == nil {
+		return errors.New("no window to show the save dialog in")
+	}
+	data, err := io.ReadAll(document)
+	if err != nil {
+		return err
+	}
+	save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+		if err == nil && w != nil {
+			_, err = io.Copy(w, bytes.NewReader(data))
+			if closeErr := w.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		if err != nil {
+			dialog.ShowError(err, window)
+		}
+	}, window)
+	save.SetFileName(fileName)
+	save.Show()
+	return nil
+}
@@ -0,0 +1,139 @@
+package print
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
+)
+
+// WatermarkPosition is the position of a watermark within the imageable area of a page.
+type WatermarkPosition int
+
+const (
+	WatermarkCenter WatermarkPosition = iota
+	WatermarkTop
+	WatermarkBottom
+	WatermarkTopLeft
+	WatermarkTopRight
+	WatermarkBottomLeft
+	WatermarkBottomRight
+)
+
+// Watermark is text or an image that is drawn over every printed sheet. Text may
+// contain the same placeholders as a HeaderFooter.
+type Watermark struct {
+	Text       string
+	TextSize   float32
+	Color      color.Color
+	DateFormat string
+	// Image is drawn instead of the text if it is not nil.
+	Image image.Image
+	// ImageSize is the size that the image is drawn at. A zero size draws the image at
+	// 72 pixels per inch.
+	ImageSize fyne.Size
+	// Rotation is the counterclockwise rotation in degrees.
+	Rotation float64
+	// Opacity is between 0 (invisible) and 1 (opaque).
+	Opacity  float64
+	Position WatermarkPosition
+}
+
+// NewTextWatermark creates a large, light gray watermark that is printed diagonally
+// across the center of each sheet.
+//
+// Params:
+//
+//	text is the watermark text, for example "DRAFT" or "CONFIDENTIAL - {user} {date}".
+func NewTextWatermark(text string) *Watermark {
+	return &Watermark{Text: text, TextSize: 72, Color: color.Gray{Y: 0x80},
+		DateFormat: "2006-01-02 15:04", Rotation: 45, Opacity: 0.3}
+}
+
+// NewImageWatermark creates a watermark that draws an image in the center of each
+// sheet.
+//
+// Params:
+//
+//	img is the image to draw.
+//	size is the size to draw the image at.
+func NewImageWatermark(img image.Image, size fyne.Size) *Watermark {
+	return &Watermark{Image: img, ImageSize: size, Opacity: 0.3}
+}
+
+// layer renders the unrotated watermark for a page.
+func (w *Watermark) layer(pc *PrintContext, info pageInfo) image.Image {
+	if w.Image != nil {
+		size := w.ImageSize
+		if size.IsZero() {
+			b := w.Image.Bounds()
+			size = fyne.NewSize(float32(b.Dx()), float32(b.Dy()))
+		}
+		dst := image.NewNRGBA(image.Rect(0, 0, pc.toPixels(size.Width),
+			pc.toPixels(size.Height)))
+		xdraw.ApproxBiLinear.Scale(dst, dst.Bounds(), w.Image, w.Image.Bounds(), xdraw.Src, nil)
+		return dst
+	}
+	c := w.Color
+	if c == nil {
+		c = color.Black
+	}
+	t := canvas.NewText(info.expand(w.Text, w.DateFormat), c)
+	t.TextSize = w.TextSize
+	return renderObject(t, t.MinSize(), pc.dpi)
+}
+
+// draw draws the watermark onto a page.
+func (w *Watermark) draw(page draw.Image, pc *PrintContext, info pageInfo) {
+	layer := rotate(w.layer(pc, info), w.Rotation)
+	size := layer.Bounds().Size()
+	origin := image.Pt(pc.toPixels(pc.margins.left), pc.toPixels(pc.margins.top))
+	area := image.Pt(pc.toPixels(pc.ImageableSize().Width), pc.toPixels(pc.ImageableSize().Height))
+	x, y := (area.X-size.X)/2, (area.Y-size.Y)/2
+	switch w.Position {
+	case WatermarkTop, WatermarkTopLeft, WatermarkTopRight:
+		y = 0
+	case WatermarkBottom, WatermarkBottomLeft, WatermarkBottomRight:
+		y = area.Y - size.Y
+	}
+	switch w.Position {
+	case WatermarkTopLeft, WatermarkBottomLeft:
+		x = 0
+	case WatermarkTopRight, WatermarkBottomRight:
+		x = area.X - size.X
+	}
+	at := origin.Add(image.Pt(x, y))
+	opacity := math.Max(0, math.Min(1, w.Opacity))
+	mask := image.NewUniform(color.Alpha{A: uint8(opacity*0xff + 0.5)})
+	draw.DrawMask(page, layer.Bounds().Add(at), layer, layer.Bounds().Min, mask,
+		image.Point{}, draw.Over)
+}
+
+// rotate returns img rotated counterclockwise by degrees, in an image that is just
+// large enough to hold it.
+func rotate(img image.Image, degrees float64) image.Image {
+	if math.Mod(degrees, 360) == 0 {
+		return img
+	}
+	rad := degrees * math.Pi / 180
+	sin, cos := math.Sin(rad), math.Cos(rad)
+	b := img.Bounds()
+	w, h := float64(b.Dx()), float64(b.Dy())
+	rw := math.Abs(w*cos) + math.Abs(h*sin)
+	rh := math.Abs(w*sin) + math.Abs(h*cos)
+	dst := image.NewNRGBA(image.Rect(0, 0, int(math.Ceil(rw-1e-9)), int(math.Ceil(rh-1e-9))))
+
+	// Map source coordinates to destination coordinates, rotating about the centers.
+	cx, cy := float64(b.Min.X)+w/2, float64(b.Min.Y)+h/2
+	s2d := f64.Aff3{
+		cos, sin, rw/2 - cos*cx - sin*cy,
+		-sin, cos, rh/2 + sin*cx - cos*cy,
+	}
+	xdraw.ApproxBiLinear.Transform(dst, s2d, img, b, xdraw.Over, nil)
+	return dst
+}
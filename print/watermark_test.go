@@ -0,0 +1,59 @@
+package print
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateDegrees(t *testing.T) {
+	img := newTestPage(10, 4, color.Black)
+	assert.Equal(t, img, rotate(img, 0))
+	assert.Equal(t, image.Rect(0, 0, 4, 10), rotate(img, 90).Bounds())
+	r := rotate(img, 45)
+	assert.Equal(t, image.Rect(0, 0, 10, 10), r.Bounds())
+	assert.NotEqual(t, uint32(0), alphaAt(r, 5, 5))
+	assert.Equal(t, uint32(0), alphaAt(r, 0, 0))
+}
+
+func TestWatermark_Draw(t *testing.T) {
+	pc := newPrintContext(fyne.NewSize(40, 40), Margins{top: 5, bottom: 5, left: 5, right: 5}, 72)
+	w := NewImageWatermark(newTestPage(10, 10, color.Black), fyne.NewSize(10, 10))
+	w.Opacity = 0.5
+
+	page := pc.newPageImage()
+	w.draw(page, pc, pageInfo{})
+	assert.Equal(t, uint32(0), alphaAt(page, 14, 20))
+	assert.Equal(t, uint32(0x8080), alphaAt(page, 20, 20))
+
+	page = pc.newPageImage()
+	w.Position = WatermarkBottomRight
+	w.draw(page, pc, pageInfo{})
+	assert.Equal(t, uint32(0x8080), alphaAt(page, 34, 34))
+	assert.Equal(t, uint32(0), alphaAt(page, 35, 35))
+}
+
+func TestWatermark_Text(t *testing.T) {
+	test.NewApp()
+	pc := newPrintContext(fyne.NewSize(200, 200), Margins{}, 72)
+	w := NewTextWatermark("DRAFT {user}")
+	info := pageInfo{date: time.Now(), user: "pat"}
+	assert.Equal(t, "DRAFT pat", info.expand(w.Text, w.DateFormat))
+	layer := w.layer(pc, info)
+	assert.Greater(t, layer.Bounds().Dx(), layer.Bounds().Dy())
+
+	page := pc.newPageImage()
+	w.draw(page, pc, info)
+	var painted bool
+	for y := 0; y < 200 && !painted; y++ {
+		for x := 0; x < 200 && !painted; x++ {
+			painted = alphaAt(page, x, y) != 0
+		}
+	}
+	assert.True(t, painted)
+}
@@ -3,6 +3,7 @@
 package print
 
 import (
+	"errors"
 	"syscall"
 	"unsafe"
 
@@ -80,6 +81,7 @@ var (
 	procCreateDC      = modgdi32.NewProc("CreateDCW")
 	procDeleteDC      = modgdi32.NewProc("DeleteDC")
 	procGetDeviceCaps = modgdi32.NewProc("GetDeviceCaps")
+	procGetICMProfile = modgdi32.NewProc("GetICMProfileW")
 )
 
 // createDC creates a device context for the named printer.
@@ -112,3 +114,19 @@ func getDeviceCaps(dc syscall.Handle, item int32) int32 {
 	r1, _, _ := procGetDeviceCaps.Call(uintptr(dc), uintptr(item))
 	return int32(r1)
 }
+
+// getICMProfile returns the file name of the color profile of a device context.
+func getICMProfile(dc syscall.Handle) (string, error) {
+	var size uint32
+	procGetICMProfile.Call(uintptr(dc), uintptr(unsafe.Pointer(&size)), 0)
+	if size == 0 {
+		return "", errors.New("the printer has no color profile")
+	}
+	name := make([]uint16, size)
+	r1, _, err := procGetICMProfile.Call(uintptr(dc), uintptr(unsafe.Pointer(&size)),
+		uintptr(unsafe.Pointer(&name[0])))
+	if r1 == 0 {
+		return "", err
+	}
+	return windows.UTF16ToString(name), nil
+}
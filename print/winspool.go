@@ -28,12 +28,19 @@ const (
 var (
 	modwinspool = syscall.NewLazyDLL("winspool.drv")
 
+	procAbortPrinter       = modwinspool.NewProc("AbortPrinter")
 	procClosePrinter       = modwinspool.NewProc("ClosePrinter")
 	procDeviceCapabilities = modwinspool.NewProc("DeviceCapabilitiesW")
+	procEndDocPrinter      = modwinspool.NewProc("EndDocPrinter")
+	procEndPagePrinter     = modwinspool.NewProc("EndPagePrinter")
 	procEnumForms          = modwinspool.NewProc("EnumFormsW")
 	procEnumPrinters       = modwinspool.NewProc("EnumPrintersW")
 	procGetDefaultPrinter  = modwinspool.NewProc("GetDefaultPrinterW")
+	procGetPrinter         = modwinspool.NewProc("GetPrinterW")
 	procOpenPrinter        = modwinspool.NewProc("OpenPrinterW")
+	procStartDocPrinter    = modwinspool.NewProc("StartDocPrinterW")
+	procStartPagePrinter   = modwinspool.NewProc("StartPagePrinter")
+	procWritePrinter       = modwinspool.NewProc("WritePrinter")
 )
 
 // closePrinter closes the printer.
@@ -116,6 +123,28 @@ func enumPrinters(flags uint32,
 	}
 */
 
+// getPrinter retrieves information about a printer.
+// See https://learn.microsoft.com/en-us/windows/win32/printdocs/getprinter for information
+// on the arguments.
+//
+// Returns:
+//
+//	A bool indicating if the function succeeded.
+//	An error if the function failed.
+func getPrinter(printerHandle syscall.Handle,
+	level uint32,
+	buf *byte,
+	cbBuf uint32,
+	needed *uint32) (bool, error) {
+	r1, _, err := procGetPrinter.Call(
+		uintptr(printerHandle),
+		uintptr(level),
+		uintptr(unsafe.Pointer(buf)),
+		uintptr(cbBuf),
+		uintptr(unsafe.Pointer(needed)))
+	return r1 != 0, err
+}
+
 func openPrinter(pName string, printerDefs *PrinterDefaults) syscall.Handle {
 	name, _ := syscall.UTF16FromString(pName)
 	var prHandle syscall.Handle
@@ -161,3 +190,79 @@ func enumForms(printerHandle syscall.Handle, level uint32, forms []formInfo2,
 	}
 	return forms, nil
 }
+
+// docInfo1 is the Win32 DOC_INFO_1W struct that describes a document passed to
+// startDocPrinter.
+type docInfo1 struct {
+	docName    *uint16
+	outputFile *uint16
+	datatype   *uint16
+}
+
+// startDocPrinter notifies the print spooler that a document is to be spooled for printing.
+// See https://learn.microsoft.com/en-us/windows/win32/printdocs/startdocprinter for
+// information on the arguments.
+//
+// Returns the print job identifier, or 0 on error.
+func startDocPrinter(printerHandle syscall.Handle, docInfo *docInfo1) (uint32, error) {
+	r1, _, err := procStartDocPrinter.Call(
+		uintptr(printerHandle),
+		1,
+		uintptr(unsafe.Pointer(docInfo)))
+	if r1 == 0 {
+		return 0, err
+	}
+	return uint32(r1), nil
+}
+
+// startPagePrinter notifies the spooler that a page is about to be printed.
+func startPagePrinter(printerHandle syscall.Handle) error {
+	r1, _, err := procStartPagePrinter.Call(uintptr(printerHandle))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// writePrinter sends data directly to the printer.
+//
+// Returns the number of bytes written.
+func writePrinter(printerHandle syscall.Handle, buf []byte) (uint32, error) {
+	var written uint32
+	r1, _, err := procWritePrinter.Call(
+		uintptr(printerHandle),
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(len(buf)),
+		uintptr(unsafe.Pointer(&written)))
+	if r1 == 0 {
+		return written, err
+	}
+	return written, nil
+}
+
+// endPagePrinter notifies the spooler that the application is at the end of a page.
+func endPagePrinter(printerHandle syscall.Handle) error {
+	r1, _, err := procEndPagePrinter.Call(uintptr(printerHandle))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// endDocPrinter ends a print job for the printer.
+func endDocPrinter(printerHandle syscall.Handle) error {
+	r1, _, err := procEndDocPrinter.Call(uintptr(printerHandle))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// abortPrinter deletes the spool file of the printer's current print job.
+func abortPrinter(printerHandle syscall.Handle) error {
+	r1, _, err := procAbortPrinter.Call(uintptr(printerHandle))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}